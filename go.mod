@@ -7,9 +7,7 @@ require (
 	github.com/jolt9dev/go-exec v0.0.1
 	github.com/jolt9dev/go-fs v0.0.0
 	github.com/jolt9dev/go-platform v0.0.0
+	github.com/jolt9dev/go-xstrings v0.0.0
 )
 
-require (
-	github.com/jolt9dev/go-xstrings v0.0.0 // indirect
-	golang.org/x/sys v0.28.0 // indirect
-)
+require golang.org/x/sys v0.28.0 // indirect
@@ -0,0 +1,74 @@
+// Package busybox detects whether a shell executable is provided
+// by busybox, e.g. /bin/sh on Alpine, which only supports a subset
+// of the flags of the shells it replaces.
+package busybox
+
+import (
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var cache sync.Map
+
+// Reports whether the executable is busybox by its name, the target
+// of its symlink or, for hard links, whether it is the same file as
+// the busybox binary. The executable is never run. The result is
+// cached per path.
+func Is(exe string) bool {
+	if exe == "" {
+		return false
+	}
+
+	if v, ok := cache.Load(exe); ok {
+		return v.(bool)
+	}
+
+	is := probe(exe)
+	cache.Store(exe, is)
+	return is
+}
+
+// Reports whether the file name of the executable is busybox
+func IsName(exe string) bool {
+	name := strings.ToLower(exe)
+	if i := strings.LastIndexAny(name, "/\\"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return strings.TrimSuffix(name, ".exe") == "busybox"
+}
+
+// detects busybox without running the executable, since probing
+// runs while commands are built and could start WSL on windows
+func probe(exe string) bool {
+	if IsName(exe) {
+		return true
+	}
+
+	if target, err := filepath.EvalSymlinks(exe); err == nil && IsName(target) {
+		return true
+	}
+
+	// applets installed as hard links share the file of busybox,
+	// copies of busybox are not detected
+	fi, err := os.Stat(exe)
+	if err != nil {
+		return false
+	}
+
+	candidates := []string{"/bin/busybox", "/usr/bin/busybox"}
+	if p, err := osexec.LookPath("busybox"); err == nil {
+		candidates = append(candidates, p)
+	}
+
+	for _, candidate := range candidates {
+		if bb, err := os.Stat(candidate); err == nil && os.SameFile(fi, bb) {
+			return true
+		}
+	}
+
+	return false
+}
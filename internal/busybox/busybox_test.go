@@ -0,0 +1,80 @@
+package busybox
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestIsName(t *testing.T) {
+	tests := []struct {
+		exe  string
+		want bool
+	}{
+		{"busybox", true},
+		{"/bin/busybox", true},
+		{`C:\tools\BusyBox.exe`, true},
+		{"/bin/sh", false},
+		{"busybox-extras", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsName(tt.exe); got != tt.want {
+			t.Errorf("IsName(%q) = %v, want %v", tt.exe, got, tt.want)
+		}
+	}
+}
+
+func TestIsDoesNotRunTheExecutable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the executable")
+	}
+
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+	exe := filepath.Join(dir, "sh")
+	script := "#!/bin/sh\ntouch " + marker + "\necho BusyBox v1.36\n"
+	if err := os.WriteFile(exe, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if Is(exe) {
+		t.Error("a script that prints the banner is not busybox")
+	}
+
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("the executable was run")
+	}
+}
+
+func TestIsLinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("links require privileges on windows")
+	}
+
+	dir := t.TempDir()
+	bb := filepath.Join(dir, "busybox")
+	if err := os.WriteFile(bb, []byte("binary"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	symlink := filepath.Join(dir, "ash")
+	if err := os.Symlink(bb, symlink); err != nil {
+		t.Fatal(err)
+	}
+
+	if !Is(symlink) {
+		t.Error("symlink to busybox not detected")
+	}
+
+	other := filepath.Join(dir, "other")
+	if err := os.WriteFile(other, []byte("binary"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if Is(other) {
+		t.Error("unrelated file detected as busybox")
+	}
+}
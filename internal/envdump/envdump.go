@@ -0,0 +1,68 @@
+// Package envdump parses the environment a script dumps before and
+// after it runs so that the shell packages can report the variables
+// the script set.
+package envdump
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// ErrMissingDump is returned by Diff when the stdout of the script
+// does not hold both dumps, e.g. because the script called exit.
+var ErrMissingDump = errors.New("the environment was not dumped after the script, it may have called exit")
+
+// Returns a random marker that separates the dumps from the output
+// of the script
+func Marker() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "__ENV_" + hex.EncodeToString(b) + "__", nil
+}
+
+// Returns the variables that were added or changed by the script.
+// The stdout of the script must be made of the marker followed by
+// a NUL, the environment before the script as NUL terminated
+// name=value entries, the marker and a NUL again, the output of the
+// script, and the marker and a NUL followed by the environment after
+// the script. Variables the script removed are not reported.
+func Diff(stdout []byte, marker string) (map[string]string, error) {
+	parts := bytes.Split(stdout, []byte(marker+"\x00"))
+	if len(parts) != 4 {
+		return nil, ErrMissingDump
+	}
+
+	before := parse(parts[1])
+	after := parse(parts[3])
+	changed := map[string]string{}
+	for name, value := range after {
+		if prev, ok := before[name]; !ok || prev != value {
+			changed[name] = value
+		}
+	}
+
+	return changed, nil
+}
+
+// parses NUL terminated name=value entries, values may span lines
+func parse(dump []byte) map[string]string {
+	env := map[string]string{}
+	for _, entry := range strings.Split(string(dump), "\x00") {
+		// names such as =C: on windows start with an equals sign
+		i := strings.Index(entry[min(1, len(entry)):], "=")
+		if i < 0 {
+			continue
+		}
+
+		i += min(1, len(entry))
+		env[entry[:i]] = entry[i+1:]
+	}
+
+	return env
+}
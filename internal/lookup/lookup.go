@@ -0,0 +1,79 @@
+// Package lookup lists the locations probed for the executables
+// registered with go-exec.
+package lookup
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/jolt9dev/go-env"
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-fs"
+)
+
+// Returns the locations probed for the registered executables on
+// the current os in order, starting with the value of the override
+// variable of each executable when set. Environment variables such
+// as ${ProgramFiles} are expanded and locations that expand to an
+// empty string are omitted. PATH is searched after these.
+func Candidates(names ...string) []string {
+	paths := []string{}
+	for _, name := range names {
+		exe, ok := exec.Registry.Get(name)
+		if !ok {
+			continue
+		}
+
+		if v := Unquote(env.Get(exe.Variable)); exe.Variable != "" && v != "" {
+			paths = append(paths, v)
+		}
+
+		list := exe.Linux
+		switch runtime.GOOS {
+		case "windows":
+			list = exe.Windows
+		case "darwin":
+			list = append(append([]string{}, exe.Darwin...), exe.Linux...)
+		}
+
+		for _, p := range list {
+			p, _ = env.Expand(p)
+			if p != "" {
+				paths = append(paths, p)
+			}
+		}
+	}
+
+	return paths
+}
+
+// Returns the path with surrounding whitespace and double quotes
+// removed, e.g. from BASH_PATH="\"C:\\Program Files\\Git\\bin\\bash.exe\""
+// which is commonly quoted on windows since the path has spaces.
+// The path itself is passed to the process as is, the command
+// line is quoted when the process is started.
+func Unquote(path string) string {
+	path = strings.TrimSpace(path)
+	if len(path) >= 2 && path[0] == '"' && path[len(path)-1] == '"' {
+		path = strings.TrimSpace(path[1 : len(path)-1])
+	}
+
+	return path
+}
+
+// Returns the unquoted and expanded path from the environment
+// variable when it is an existing file, otherwise an empty string.
+// go-exec ignores a quoted path in the variable.
+func FromEnv(variable string) string {
+	path := Unquote(env.Get(variable))
+	if path == "" {
+		return ""
+	}
+
+	path, _ = env.Expand(path)
+	if path == "" || !fs.IsFile(path) {
+		return ""
+	}
+
+	return path
+}
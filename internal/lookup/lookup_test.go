@@ -0,0 +1,97 @@
+package lookup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnquote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{`/bin/bash`, "/bin/bash"},
+		{`  /bin/bash  `, "/bin/bash"},
+		{`"C:\Program Files\Git\bin\bash.exe"`, `C:\Program Files\Git\bin\bash.exe`},
+		{`" /opt/my shell/bash "`, "/opt/my shell/bash"},
+		{`"`, `"`},
+		{`"unterminated`, `"unterminated`},
+		{`""`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Unquote(tt.in); got != tt.want {
+				t.Errorf("Unquote(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// writes an empty executable named name into a new temp directory
+// whose name contains a space and returns its path
+func fakeExe(t *testing.T, name string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "my shells")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(dir, name)
+	if err := os.WriteFile(file, []byte("#!/bin/sh\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+func TestFromEnvQuotedPathWithSpaces(t *testing.T) {
+	exe := fakeExe(t, "bash")
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"unquoted", exe},
+		{"quoted", `"` + exe + `"`},
+		{"quoted with whitespace", ` "` + exe + `" `},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SPAWN_TEST_PATH", tt.value)
+			if got := FromEnv("SPAWN_TEST_PATH"); got != exe {
+				t.Errorf("FromEnv() = %q, want %q", got, exe)
+			}
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	exe := fakeExe(t, "ksh")
+	home := filepath.Dir(exe)
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"unset", "", ""},
+		{"path", exe, exe},
+		{"home", "${HOME}/ksh", filepath.Join(home, "ksh")},
+		{"quoted home", `"${HOME}/ksh"`, filepath.Join(home, "ksh")},
+		{"missing", filepath.Join(home, "missing"), ""},
+		{"directory", home, ""},
+		{"unknown variable", "${SPAWN_TEST_UNSET}", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", home)
+			t.Setenv("SPAWN_TEST_PATH", tt.value)
+			if got := FromEnv("SPAWN_TEST_PATH"); filepath.Clean(got) != filepath.Clean(tt.want) {
+				t.Errorf("FromEnv() with %q = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
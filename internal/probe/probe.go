@@ -0,0 +1,29 @@
+// Package probe checks whether a shell executable accepts a script,
+// which detects the features of the installed shell version.
+package probe
+
+import (
+	osexec "os/exec"
+	"strings"
+	"sync"
+)
+
+var cache sync.Map
+
+// Reports whether running the executable with the arguments exits
+// with zero, e.g. ksh -c "set -o pipefail". The result is cached
+// per executable and arguments. Always false for an empty path.
+func Succeeds(exe string, args ...string) bool {
+	if exe == "" {
+		return false
+	}
+
+	key := exe + "\x00" + strings.Join(args, "\x00")
+	if v, ok := cache.Load(key); ok {
+		return v.(bool)
+	}
+
+	ok := osexec.Command(exe, args...).Run() == nil
+	cache.Store(key, ok)
+	return ok
+}
@@ -0,0 +1,111 @@
+package proc
+
+import (
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Returns the environment with the values from env set on top of
+// the current process environment. When clear is true, only the
+// values from env are returned.
+func MergeEnv(env map[string]string, clear bool) []string {
+	base := []string{}
+	if !clear {
+		base = os.Environ()
+	}
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	merged := make([]string, 0, len(base)+len(keys))
+	for _, kv := range base {
+		k, _, _ := strings.Cut(kv, "=")
+		if !hasKey(keys, k) {
+			merged = append(merged, kv)
+		}
+	}
+
+	for _, k := range keys {
+		merged = append(merged, k+"="+env[k])
+	}
+
+	return merged
+}
+
+// Returns the value of key in the environment list
+func LookupEnv(env []string, key string) (string, bool) {
+	for i := len(env) - 1; i >= 0; i-- {
+		k, v, _ := strings.Cut(env[i], "=")
+		if equalKey(k, key) {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+func hasKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if equalKey(k, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// environment variable names are case insensitive on windows
+func equalKey(a, b string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+
+	return a == b
+}
+
+// the PATH used by CleanEnv when the current process has none
+var defaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// Returns a minimal environment with only the given variables of
+// the current process plus PATH, and SystemRoot on windows which
+// processes need to load system libraries. Variables that are not
+// set are omitted. When PATH is not set, a default search path is
+// used so that the shell can still find binaries.
+func CleanEnv(keep []string) map[string]string {
+	keep = append([]string{"PATH"}, keep...)
+	if runtime.GOOS == "windows" {
+		keep = append(keep, "SystemRoot")
+	}
+
+	env := map[string]string{}
+	hasPath := false
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		if hasKey(keep, k) {
+			env[k] = v
+			hasPath = hasPath || equalKey(k, "PATH")
+		}
+	}
+
+	if !hasPath {
+		path := defaultPath
+		if runtime.GOOS == "windows" {
+			root := env["SystemRoot"]
+			if root == "" {
+				root = "C:\\Windows"
+			}
+
+			path = root + "\\System32;" + root
+		}
+
+		env["PATH"] = path
+	}
+
+	return env
+}
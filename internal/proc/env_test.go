@@ -0,0 +1,87 @@
+package proc
+
+import (
+	"maps"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestMergeEnv(t *testing.T) {
+	t.Setenv("SPAWN_TEST_PARENT", "parent")
+
+	tests := []struct {
+		name   string
+		env    map[string]string
+		clear  bool
+		want   map[string]string
+		absent []string
+	}{
+		{"inherit", nil, false, map[string]string{"SPAWN_TEST_PARENT": "parent"}, nil},
+		{"add", map[string]string{"SPAWN_TEST_CHILD": "child"}, false, map[string]string{"SPAWN_TEST_PARENT": "parent", "SPAWN_TEST_CHILD": "child"}, nil},
+		{"override", map[string]string{"SPAWN_TEST_PARENT": "child"}, false, map[string]string{"SPAWN_TEST_PARENT": "child"}, nil},
+		{"empty value", map[string]string{"SPAWN_TEST_PARENT": ""}, false, map[string]string{"SPAWN_TEST_PARENT": ""}, nil},
+		{"clear", map[string]string{"SPAWN_TEST_CHILD": "child"}, true, map[string]string{"SPAWN_TEST_CHILD": "child"}, []string{"SPAWN_TEST_PARENT"}},
+		{"clear empty", nil, true, nil, []string{"SPAWN_TEST_PARENT"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := MergeEnv(tt.env, tt.clear)
+			for k, want := range tt.want {
+				got, ok := LookupEnv(env, k)
+				if !ok || got != want {
+					t.Errorf("%s = %q, %v, want %q", k, got, ok, want)
+				}
+			}
+
+			for _, k := range tt.absent {
+				if v, ok := LookupEnv(env, k); ok {
+					t.Errorf("%s = %q, want it unset", k, v)
+				}
+			}
+
+			count := 0
+			for _, kv := range env {
+				if strings.HasPrefix(kv, "SPAWN_TEST_PARENT=") {
+					count++
+				}
+			}
+
+			if count > 1 {
+				t.Errorf("SPAWN_TEST_PARENT is set %d times", count)
+			}
+		})
+	}
+}
+
+func TestCleanEnv(t *testing.T) {
+	t.Setenv("SPAWN_TEST_SECRET", "secret")
+	t.Setenv("SPAWN_TEST_KEEP", "keep")
+	t.Setenv("PATH", "/custom/bin")
+
+	env := CleanEnv([]string{"SPAWN_TEST_KEEP", "SPAWN_TEST_MISSING"})
+	want := map[string]string{"PATH": "/custom/bin", "SPAWN_TEST_KEEP": "keep"}
+	if runtime.GOOS == "windows" {
+		want["SystemRoot"] = os.Getenv("SystemRoot")
+	}
+
+	if !maps.Equal(env, want) {
+		t.Errorf("CleanEnv = %v, want %v", env, want)
+	}
+}
+
+func TestCleanEnvDefaultPath(t *testing.T) {
+	t.Setenv("PATH", "")
+	os.Unsetenv("PATH")
+
+	env := CleanEnv(nil)
+	if env["PATH"] == "" {
+		t.Fatalf("CleanEnv = %v, want a default PATH", env)
+	}
+
+	if runtime.GOOS != "windows" && env["PATH"] != defaultPath {
+		t.Errorf("PATH = %q, want %q", env["PATH"], defaultPath)
+	}
+}
@@ -0,0 +1,105 @@
+package proc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrOutputTruncated is returned by Output, Combined and Stream
+// when a captured stream exceeded the limit set with
+// WithOutputLimit. The returned error wraps it, so check for it
+// with errors.Is.
+var ErrOutputTruncated = errors.New("output exceeded the limit and was truncated")
+
+type outputLimitKey struct{}
+
+// Returns a context that limits the bytes Output, Combined and
+// Stream capture per stream. When a stream exceeds the limit, the rest
+// is discarded, the process tree is killed and ErrOutputTruncated
+// is returned. A limit of zero or less means no limit.
+func WithOutputLimit(ctx context.Context, limit int) context.Context {
+	if limit <= 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, outputLimitKey{}, limit)
+}
+
+func outputLimitFrom(ctx context.Context) int {
+	limit, _ := ctx.Value(outputLimitKey{}).(int)
+	return limit
+}
+
+// captures up to limit bytes and calls exceeded once when more
+// bytes are written
+type limitWriter struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+	exceeded  func()
+}
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.limit <= 0 {
+		return w.buf.Write(p)
+	}
+
+	n := len(p)
+	if rest := w.limit - w.buf.Len(); rest < len(p) {
+		p = p[:max(rest, 0)]
+		if !w.truncated {
+			w.truncated = true
+			w.exceeded()
+		}
+	}
+
+	w.buf.Write(p)
+
+	// report the whole write so that the copy keeps draining the
+	// pipe until the process is killed
+	return n, nil
+}
+
+func (w *limitWriter) Bytes() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Bytes()
+}
+
+// creates the writers for the captured streams of the command and
+// returns the channel that is closed when a stream exceeds the limit
+// along with a func that reports the truncation. The channel is nil
+// when no limit is set.
+func limitOutput(ctx context.Context, cmd string, streams int) ([]*limitWriter, <-chan struct{}, func(err error) error) {
+	limit := outputLimitFrom(ctx)
+	var exceeded chan struct{}
+	var once sync.Once
+	if limit > 0 {
+		exceeded = make(chan struct{})
+	}
+
+	writers := make([]*limitWriter, streams)
+	for i := range writers {
+		writers[i] = &limitWriter{limit: limit, exceeded: func() {
+			once.Do(func() { close(exceeded) })
+		}}
+	}
+
+	check := func(err error) error {
+		for _, w := range writers {
+			if w.truncated {
+				return fmt.Errorf("command %s: %w after %d bytes", cmd, ErrOutputTruncated, limit)
+			}
+		}
+
+		return err
+	}
+
+	return writers, exceeded, check
+}
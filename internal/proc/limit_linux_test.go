@@ -0,0 +1,78 @@
+package proc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+func TestOutputLimit(t *testing.T) {
+	const limit = 4096
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, cmd *exec.Cmd) (*exec.PsOutput, error)
+	}{
+		{"output", Output},
+		{"combined", Combined},
+		{"stream", func(ctx context.Context, cmd *exec.Cmd) (*exec.PsOutput, error) {
+			return Stream(ctx, cmd, func(string, string) {})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the trailing yes keeps writing until the process tree
+			// is killed, the sleep is a child that must go with it
+			cmd := exec.New("sh", "-c", "sleep 30 & echo $!; yes")
+			ctx := WithOutputLimit(context.Background(), limit)
+
+			start := time.Now()
+			out, err := tt.run(ctx, cmd)
+			if !errors.Is(err, ErrOutputTruncated) {
+				t.Fatalf("err = %v, want ErrOutputTruncated", err)
+			}
+
+			if elapsed := time.Since(start); elapsed > 10*time.Second {
+				t.Fatalf("the command ran for %v after the limit was exceeded", elapsed)
+			}
+
+			if len(out.Stdout) != limit {
+				t.Errorf("captured %d bytes, want %d", len(out.Stdout), limit)
+			}
+
+			first, _, _ := strings.Cut(string(out.Stdout), "\n")
+			child, err := strconv.Atoi(first)
+			if err != nil {
+				t.Fatalf("first line = %q", first)
+			}
+
+			deadline := time.Now().Add(2 * time.Second)
+			for alive(child) && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			if alive(child) {
+				syscall.Kill(child, syscall.SIGKILL)
+				t.Errorf("the child %d of the command is still running", child)
+			}
+		})
+	}
+}
+
+func TestOutputLimitNotExceeded(t *testing.T) {
+	cmd := exec.New("sh", "-c", "printf 12345678")
+	out, err := Output(WithOutputLimit(context.Background(), 8), cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out.Stdout) != "12345678" {
+		t.Errorf("stdout = %q, want %q", out.Stdout, "12345678")
+	}
+}
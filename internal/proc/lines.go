@@ -0,0 +1,46 @@
+package proc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Splits the output into lines with surrounding whitespace
+// trimmed and trailing empty lines removed. Both LF and CRLF
+// line endings are handled.
+func Lines(data []byte) []string {
+	text := strings.TrimRight(string(data), " \t\r\n")
+	if text == "" {
+		return []string{}
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	return lines
+}
+
+// Returns the trimmed stdout of the output when the command
+// succeeded. Otherwise an error is returned that includes the
+// exit code and the trimmed stderr and wraps the run error.
+func Text(name string, out *exec.PsOutput, err error) (string, error) {
+	if err == nil && out.Code == 0 {
+		return strings.TrimSpace(string(out.Stdout)), nil
+	}
+
+	if err == nil {
+		err = errors.New("non-zero exit code")
+	}
+
+	stderr := strings.TrimSpace(string(out.Stderr))
+	if stderr == "" {
+		return "", fmt.Errorf("%s exited with code %d: %w", name, out.Code, err)
+	}
+
+	return "", fmt.Errorf("%s exited with code %d: %w: %s", name, out.Code, err, stderr)
+}
@@ -0,0 +1,65 @@
+package proc
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// PrefixWriter writes each line to the underlying writer with the
+// prefix prepended. Partial lines are buffered until a newline or
+// Flush so that the prefix always lands at a line boundary and
+// every line is written with a single call, which keeps lines of
+// concurrent commands sharing a writer from interleaving.
+type PrefixWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+// Creates a new PrefixWriter that writes to w.
+func NewPrefixWriter(w io.Writer, prefix string) *PrefixWriter {
+	return &PrefixWriter{w: w, prefix: []byte(prefix)}
+}
+
+func (p *PrefixWriter) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		if err := p.writeLine(p.buf[:i+1]); err != nil {
+			return len(b), err
+		}
+
+		p.buf = p.buf[i+1:]
+	}
+
+	return len(b), nil
+}
+
+// Writes the buffered partial line, if any, followed by a newline.
+func (p *PrefixWriter) Flush() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.buf) == 0 {
+		return nil
+	}
+
+	line := append(p.buf, '\n')
+	p.buf = nil
+	return p.writeLine(line)
+}
+
+func (p *PrefixWriter) writeLine(line []byte) error {
+	data := make([]byte, 0, len(p.prefix)+len(line))
+	data = append(data, p.prefix...)
+	data = append(data, line...)
+	_, err := p.w.Write(data)
+	return err
+}
@@ -0,0 +1,191 @@
+// Package proc contains the process handling shared by the
+// shell packages such as running commands under a context
+// and killing process trees.
+package proc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Runs the command with stdout, stderr and stdin inherited from
+// the current process. The process tree is killed when the
+// context is cancelled or its deadline is exceeded.
+func Run(ctx context.Context, cmd *exec.Cmd) (*exec.PsOutput, error) {
+	if fn := runnerFrom(ctx); fn != nil {
+		return fn(cmd)
+	}
+
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return Wait(ctx, cmd)
+}
+
+// Runs the command and captures stdout and stderr. The process
+// tree is killed when the context is cancelled or its deadline
+// is exceeded and any output captured so far is returned. See
+// WithOutputLimit to limit the captured output.
+func Output(ctx context.Context, cmd *exec.Cmd) (*exec.PsOutput, error) {
+	if fn := runnerFrom(ctx); fn != nil {
+		return fn(cmd)
+	}
+
+	w, exceeded, check := limitOutput(ctx, cmd.Path, 2)
+	cmd.Stdout = w[0]
+	cmd.Stderr = w[1]
+	out, err := wait(ctx, cmd, 0, exceeded)
+	out.Stdout = w[0].Bytes()
+	out.Stderr = w[1].Bytes()
+	return out, check(err)
+}
+
+// Runs the command and captures stdout and stderr interleaved in
+// a single buffer like 2>&1, which is returned as the stdout of
+// the output. Both streams share the same pipe so the order is
+// the order the process wrote them, however the ordering is best
+// effort since the process may buffer each stream differently.
+func Combined(ctx context.Context, cmd *exec.Cmd) (*exec.PsOutput, error) {
+	if fn := runnerFrom(ctx); fn != nil {
+		return fn(cmd)
+	}
+
+	w, exceeded, check := limitOutput(ctx, cmd.Path, 1)
+	cmd.Stdout = w[0]
+	cmd.Stderr = w[0]
+	out, err := wait(ctx, cmd, 0, exceeded)
+	out.Stdout = w[0].Bytes()
+	return out, check(err)
+}
+
+// Starts the command using the stdio already set on the command
+// and waits for it to exit. The process tree is killed when the
+// context is done and the context error is returned wrapped.
+func Wait(ctx context.Context, cmd *exec.Cmd) (*exec.PsOutput, error) {
+	return WaitGrace(ctx, cmd, 0)
+}
+
+// Starts the command the same as Wait, however when the context is
+// done the process is first asked to terminate with Terminate and
+// the process tree is only killed when it has not exited after the
+// grace period. A grace period of zero or less kills immediately.
+func WaitGrace(ctx context.Context, cmd *exec.Cmd, grace time.Duration) (*exec.PsOutput, error) {
+	return wait(ctx, cmd, grace, nil)
+}
+
+// waits for the command the same as WaitGrace and also kills the
+// process tree when stop is closed, which does not affect whether
+// the process is started in a new process group
+func wait(ctx context.Context, cmd *exec.Cmd, grace time.Duration, stop <-chan struct{}) (*exec.PsOutput, error) {
+	if fn := runnerFrom(ctx); fn != nil {
+		return fn(cmd)
+	}
+
+	var out exec.PsOutput
+	out.Stdout = make([]byte, 0)
+	out.Stderr = make([]byte, 0)
+	out.FileName = cmd.Path
+	out.Args = cmd.Args
+
+	if err := ctx.Err(); err != nil {
+		out.Code = 1
+		return &out, fmt.Errorf("command %s cancelled: %w", out.FileName, err)
+	}
+
+	// the executable could not be resolved, return the error
+	// before go-exec attempts to find it again
+	if cmd.Err != nil {
+		out.Code = 1
+		return &out, cmd.Err
+	}
+
+	if fn := beforeRunFrom(ctx); fn != nil {
+		fn(cmd)
+	}
+
+	// only detach the process group when the caller can cancel the
+	// context or signals are forwarded so that terminal signals still
+	// reach the process. A command that reads from the terminal must
+	// stay in the foreground process group, otherwise reading stops
+	// it with SIGTTIN, so its tree is killed without the group.
+	forward := forwardSignalsFrom(ctx)
+	group := (ctx.Done() != nil || forward) && !StdinIsTerminal(cmd)
+	if group {
+		SetProcessGroup(cmd)
+	}
+
+	cancellable := ctx.Done() != nil || stop != nil
+	if cancellable {
+		suspendStart(cmd)
+	}
+
+	out.StartedAt = time.Now().UTC()
+	err := cmd.Start()
+	if err != nil {
+		out.EndedAt = time.Now().UTC()
+		out.Code = 1
+		return &out, err
+	}
+
+	if forward {
+		stop := forwardSignals(cmd, group)
+		defer stop()
+	}
+
+	done := make(chan struct{})
+	killed := make(chan struct{})
+	if cancellable {
+		release := trackTree(cmd)
+		defer func() {
+			<-killed
+			release()
+		}()
+	}
+
+	go func() {
+		defer close(killed)
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		case <-done:
+			return
+		}
+
+		if grace > 0 && Terminate(cmd) == nil {
+			timer := time.NewTimer(grace)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-done:
+				return
+			}
+		}
+
+		KillTree(cmd)
+	}()
+
+	err = cmd.Wait()
+	close(done)
+	out.EndedAt = time.Now().UTC()
+	out.Code = 1
+	if cmd.ProcessState != nil {
+		out.Code = cmd.ProcessState.ExitCode()
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return &out, fmt.Errorf("command %s cancelled: %w", out.FileName, ctxErr)
+	}
+
+	return &out, err
+}
+
+// Reports whether the stdin of the command is a terminal, in which
+// case the command is not started in a new process group.
+func StdinIsTerminal(cmd *exec.Cmd) bool {
+	f, ok := cmd.Stdin.(*os.File)
+	return ok && isTerminal(f.Fd())
+}
@@ -0,0 +1,114 @@
+package proc
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// opens a new pseudo terminal and returns its controlling and
+// terminal ends
+func openPty(t *testing.T) (*os.File, *os.File) {
+	t.Helper()
+	ptmx, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("no pseudo terminals: %v", err)
+	}
+
+	t.Cleanup(func() { ptmx.Close() })
+
+	var n uint32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, ptmx.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); errno != 0 {
+		t.Skipf("TIOCGPTN: %v", errno)
+	}
+
+	var unlock int32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, ptmx.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); errno != 0 {
+		t.Skipf("TIOCSPTLCK: %v", errno)
+	}
+
+	pts, err := os.OpenFile("/dev/pts/"+strconv.Itoa(int(n)), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("open pts: %v", err)
+	}
+
+	t.Cleanup(func() { pts.Close() })
+	return ptmx, pts
+}
+
+// reports whether the process exists and is not a zombie
+func alive(pid int) bool {
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return false
+	}
+
+	// the state follows the command name in parentheses
+	stat := string(data)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	return len(fields) > 0 && fields[0] != "Z"
+}
+
+func TestStdinTerminalSkipsProcessGroup(t *testing.T) {
+	_, pts := openPty(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cmd := exec.New("sh", "-c", "true")
+	cmd.Stdin = pts
+	if !StdinIsTerminal(cmd) {
+		t.Fatal("StdinIsTerminal is false for a pseudo terminal")
+	}
+
+	if _, err := Output(ctx, cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+		t.Error("the command was started in a new process group although stdin is a terminal")
+	}
+}
+
+func TestStdinTerminalKillsTree(t *testing.T) {
+	_, pts := openPty(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	// the trailing wait keeps sh from exec'ing sleep, so the sleep
+	// is a child of sh
+	cmd := exec.New("sh", "-c", "sleep 30 & echo $!; wait")
+	cmd.Stdin = pts
+	start := time.Now()
+	out, err := Output(ctx, cmd)
+	if err == nil {
+		t.Fatal("want an error once the context is done")
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("the command ran for %v after the context was done", elapsed)
+	}
+
+	child, err := strconv.Atoi(strings.TrimSpace(string(out.Stdout)))
+	if err != nil {
+		t.Fatalf("stdout = %q", out.Stdout)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for alive(child) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if alive(child) {
+		syscall.Kill(child, syscall.SIGKILL)
+		t.Errorf("the child %d of the command is still running", child)
+	}
+}
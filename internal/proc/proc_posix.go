@@ -0,0 +1,137 @@
+//go:build !windows
+
+package proc
+
+import (
+	"os"
+	osexec "os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+var forwardedSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT}
+
+// the signals the terminal sends to its foreground process group,
+// which already includes commands that read from the terminal
+var terminalSignals = []os.Signal{syscall.SIGINT, syscall.SIGQUIT}
+
+// Starts the command in a new process group so that the
+// process and its children can be killed together.
+func SetProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// Kills the process group of the command which includes
+// any children started by the process. When the command was not
+// started in a new process group, e.g. because stdin is a terminal,
+// the process and its descendants are killed one by one instead.
+func KillTree(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+
+	err := signalTree(cmd, syscall.SIGKILL)
+	if err != nil {
+		return cmd.Process.Kill()
+	}
+
+	return nil
+}
+
+// Sends SIGTERM to the process group of the command so that the
+// process and its children can clean up before exiting.
+func Terminate(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+
+	return signalTree(cmd, syscall.SIGTERM)
+}
+
+// Sends the signal to the process group of the command.
+func Signal(cmd *exec.Cmd, sig os.Signal) error {
+	if cmd.Process == nil {
+		return nil
+	}
+
+	if s, ok := sig.(syscall.Signal); ok {
+		return signalTree(cmd, s)
+	}
+
+	return cmd.Process.Signal(sig)
+}
+
+// sends the signal to the process group of the command or, when the
+// command shares the process group of the current process, to the
+// process and each of its descendants
+func signalTree(cmd *exec.Cmd, sig syscall.Signal) error {
+	pid := cmd.Process.Pid
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+		return syscall.Kill(-pid, sig)
+	}
+
+	// stop the process first so that it neither starts new children
+	// nor exits, which orphans them, while its descendants are listed
+	syscall.Kill(pid, syscall.SIGSTOP)
+	for _, child := range descendants(pid) {
+		syscall.Kill(child, sig)
+	}
+
+	err := syscall.Kill(pid, sig)
+	syscall.Kill(pid, syscall.SIGCONT)
+	return err
+}
+
+// returns the pids of the descendants of the process using ps, which
+// is available on linux, macOS and the BSDs
+func descendants(pid int) []int {
+	data, err := osexec.Command("ps", "-A", "-o", "pid=", "-o", "ppid=").Output()
+	if err != nil {
+		return nil
+	}
+
+	children := map[int][]int{}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		child, err1 := strconv.Atoi(fields[0])
+		parent, err2 := strconv.Atoi(fields[1])
+		if err1 == nil && err2 == nil {
+			children[parent] = append(children[parent], child)
+		}
+	}
+
+	var pids []int
+	queue := children[pid]
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		pids = append(pids, next)
+		queue = append(queue, children[next]...)
+	}
+
+	return pids
+}
+
+// Does nothing since processes have no window outside of windows.
+func HideWindow(cmd *exec.Cmd) {}
+
+// does nothing since children join the process group of the command
+// regardless of when they are started
+func suspendStart(cmd *exec.Cmd) {}
+
+// does nothing since the process group of the command already
+// includes its children
+func trackTree(cmd *exec.Cmd) func() {
+	return func() {}
+}
@@ -0,0 +1,64 @@
+//go:build !windows
+
+package proc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+func TestProcessGroupGate(t *testing.T) {
+	tests := []struct {
+		name  string
+		ctx   func() (context.Context, context.CancelFunc)
+		group bool
+	}{
+		{"background", func() (context.Context, context.CancelFunc) {
+			return context.Background(), func() {}
+		}, false},
+		{"cancellable", func() (context.Context, context.CancelFunc) {
+			return context.WithCancel(context.Background())
+		}, true},
+		{"timeout", func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), time.Minute)
+		}, true},
+		{"forward signals", func() (context.Context, context.CancelFunc) {
+			return WithForwardSignals(context.Background(), true), func() {}
+		}, true},
+		{"output limit", func() (context.Context, context.CancelFunc) {
+			return WithOutputLimit(context.Background(), 1<<20), func() {}
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			cmd := exec.New("sh", "-c", `ps -o pgid= -p $$`)
+			out, err := Output(ctx, cmd)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pgid, err := strconv.Atoi(strings.TrimSpace(string(out.Stdout)))
+			if err != nil {
+				t.Skipf("ps does not report the pgid: %q", out.Stdout)
+			}
+
+			if got := pgid == cmd.Process.Pid; got != tt.group {
+				t.Errorf("new process group = %v, want %v", got, tt.group)
+			}
+
+			if !tt.group && pgid != syscall.Getpgrp() {
+				t.Errorf("pgid = %d, want the pgid %d of the test", pgid, syscall.Getpgrp())
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+//go:build windows
+
+package proc
+
+import (
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// CREATE_NO_WINDOW, which the syscall package does not define
+const createNoWindow = 0x08000000
+
+var (
+	kernel32                 = syscall.NewLazyDLL("kernel32.dll")
+	generateConsoleCtrlEvent = kernel32.NewProc("GenerateConsoleCtrlEvent")
+	createJobObject          = kernel32.NewProc("CreateJobObjectW")
+	assignProcessToJobObject = kernel32.NewProc("AssignProcessToJobObject")
+	terminateJobObject       = kernel32.NewProc("TerminateJobObject")
+
+	ntdll           = syscall.NewLazyDLL("ntdll.dll")
+	ntResumeProcess = ntdll.NewProc("NtResumeProcess")
+)
+
+// the job objects of the running commands by command
+var jobs sync.Map
+
+const (
+	processTerminate     = 0x0001
+	processSetQuota      = 0x0100
+	processSuspendResume = 0x0800
+	createSuspended      = 0x00000004
+)
+
+var forwardedSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// commands always get a new process group on windows, since reading
+// from the console does not stop processes outside of the foreground
+// group, so no signal is delivered by the console itself
+var terminalSignals []os.Signal
+
+// reports false since a new process group can still read from the
+// console on windows
+func isTerminal(fd uintptr) bool {
+	return false
+}
+
+// Starts the command in a new process group so that the
+// process and its children can be killed together.
+func SetProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	cmd.SysProcAttr.CreationFlags |= syscall.CREATE_NEW_PROCESS_GROUP
+}
+
+// Kills the process and its children since windows does not kill
+// child processes with the parent. The job object the process was
+// assigned to when it started is terminated, which includes every
+// process started by the process. When the process is not in a job
+// object, taskkill /T /F is used instead, which only finds the
+// children whose parent is still running.
+func KillTree(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+
+	if job, ok := jobs.Load(cmd); ok {
+		r, _, _ := terminateJobObject.Call(uintptr(job.(syscall.Handle)), 1)
+		if r != 0 {
+			return nil
+		}
+	}
+
+	pid := strconv.Itoa(cmd.Process.Pid)
+	err := osexec.Command("taskkill", "/T", "/F", "/PID", pid).Run()
+	if err != nil {
+		return cmd.Process.Kill()
+	}
+
+	return nil
+}
+
+// Sends CTRL_BREAK_EVENT to the process group of the command, which
+// console processes can handle to clean up before exiting. Windows
+// has no equivalent of SIGTERM, so this fails for processes that do
+// not share the console of the current process, e.g. when it runs
+// as a service, and for GUI processes.
+func Terminate(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+
+	if err := generateConsoleCtrlEvent.Find(); err != nil {
+		return err
+	}
+
+	r, _, err := generateConsoleCtrlEvent.Call(syscall.CTRL_BREAK_EVENT, uintptr(cmd.Process.Pid))
+	if r == 0 {
+		return err
+	}
+
+	return nil
+}
+
+// Sends CTRL_BREAK_EVENT to the process group of the command for
+// any signal since windows processes in a new process group do not
+// receive CTRL_C_EVENT, see Terminate.
+func Signal(cmd *exec.Cmd, sig os.Signal) error {
+	return Terminate(cmd)
+}
+
+// Starts the command without a console window so that no window
+// flashes when the current process is a GUI application.
+func HideWindow(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	cmd.SysProcAttr.HideWindow = true
+	cmd.SysProcAttr.CreationFlags |= createNoWindow
+}
+
+// starts the command suspended so that trackTree can assign it to
+// a job object before it runs and starts any children
+func suspendStart(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	cmd.SysProcAttr.CreationFlags |= createSuspended
+}
+
+// assigns the started process to a new job object so that KillTree
+// can terminate the processes it starts, and resumes the process when
+// it was started suspended with suspendStart, so every child it starts
+// is in the job as well. The job is not set to kill on close, so
+// processes that outlive a command that completes are left running.
+// Returns the func that releases the job.
+func trackTree(cmd *exec.Cmd) func() {
+	release := assignJob(cmd)
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.CreationFlags&createSuspended != 0 {
+		if err := resume(cmd); err != nil {
+			// the process would never run, so do not wait for it
+			cmd.Process.Kill()
+		}
+	}
+
+	return release
+}
+
+// assigns the started process to a new job object and returns the
+// func that releases the job
+func assignJob(cmd *exec.Cmd) func() {
+	noop := func() {}
+	if cmd.Process == nil || createJobObject.Find() != nil {
+		return noop
+	}
+
+	r, _, _ := createJobObject.Call(0, 0)
+	if r == 0 {
+		return noop
+	}
+
+	job := syscall.Handle(r)
+	h, err := syscall.OpenProcess(processSetQuota|processTerminate, false, uint32(cmd.Process.Pid))
+	if err != nil {
+		syscall.CloseHandle(job)
+		return noop
+	}
+
+	defer syscall.CloseHandle(h)
+	if r, _, _ := assignProcessToJobObject.Call(uintptr(job), uintptr(h)); r == 0 {
+		syscall.CloseHandle(job)
+		return noop
+	}
+
+	jobs.Store(cmd, job)
+	return func() {
+		jobs.Delete(cmd)
+		syscall.CloseHandle(job)
+	}
+}
+
+// resumes the threads of a process that was started suspended
+func resume(cmd *exec.Cmd) error {
+	if err := ntResumeProcess.Find(); err != nil {
+		return err
+	}
+
+	h, err := syscall.OpenProcess(processSuspendResume, false, uint32(cmd.Process.Pid))
+	if err != nil {
+		return err
+	}
+
+	defer syscall.CloseHandle(h)
+	if status, _, _ := ntResumeProcess.Call(uintptr(h)); status != 0 {
+		return fmt.Errorf("NtResumeProcess failed with status 0x%x", status)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package proc
+
+import (
+	"context"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// reports whether a process with the pid and image name is running
+func running(t *testing.T, pid, image string) bool {
+	t.Helper()
+	out, err := osexec.Command("tasklist", "/FI", "PID eq "+pid, "/NH").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.Contains(strings.ToLower(string(out)), image)
+}
+
+func TestWaitKillsJobTree(t *testing.T) {
+	ps, err := osexec.LookPath("powershell")
+	if err != nil {
+		t.Skip("powershell not found")
+	}
+
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	script := "$p = Start-Process ping -ArgumentList '-n','60','127.0.0.1' -PassThru -WindowStyle Hidden; " +
+		"Set-Content -Encoding ascii -LiteralPath '" + pidFile + "' -Value $p.Id; " +
+		"Wait-Process -Id $p.Id"
+	cmd := exec.New(ps, "-NoProfile", "-NonInteractive", "-Command", script)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for {
+			if _, err := os.Stat(pidFile); err == nil {
+				time.Sleep(200 * time.Millisecond)
+				cancel()
+				return
+			}
+
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+
+	start := time.Now()
+	if _, err := Wait(ctx, cmd); err == nil {
+		t.Fatal("expected the cancelled error")
+	}
+
+	if d := time.Since(start); d > 30*time.Second {
+		t.Fatalf("Wait returned after %s, the tree was not killed", d)
+	}
+
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pid := strings.TrimSpace(string(data))
+	deadline := time.Now().Add(5 * time.Second)
+	for running(t, pid, "ping.exe") {
+		if time.Now().After(deadline) {
+			t.Fatalf("ping %s started by the command is still running", pid)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,74 @@
+package proc
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+type runnerKey struct{}
+
+// Returns a context that makes Run, Output, Combined, Stream and
+// Wait call fn instead of spawning a process, which lets the shell
+// packages provide a test seam.
+func WithRunner(ctx context.Context, fn func(cmd *exec.Cmd) (*exec.PsOutput, error)) context.Context {
+	return context.WithValue(ctx, runnerKey{}, fn)
+}
+
+func runnerFrom(ctx context.Context) func(cmd *exec.Cmd) (*exec.PsOutput, error) {
+	fn, _ := ctx.Value(runnerKey{}).(func(cmd *exec.Cmd) (*exec.PsOutput, error))
+	return fn
+}
+
+type beforeRunKey struct{}
+
+// Returns a context that makes Wait call fn with the command right
+// before the process is started, after the stdio of the command
+// has been set.
+func WithBeforeRun(ctx context.Context, fn func(cmd *exec.Cmd)) context.Context {
+	if fn == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, beforeRunKey{}, fn)
+}
+
+func beforeRunFrom(ctx context.Context) func(cmd *exec.Cmd) {
+	fn, _ := ctx.Value(beforeRunKey{}).(func(cmd *exec.Cmd))
+	return fn
+}
+
+// calls the runner and delivers the canned output to onLine
+func streamRunner(fn func(cmd *exec.Cmd) (*exec.PsOutput, error), cmd *exec.Cmd, onLine func(stream string, line string)) (*exec.PsOutput, error) {
+	out, err := fn(cmd)
+	if out != nil {
+		for _, s := range []struct {
+			name string
+			data []byte
+		}{{"stdout", out.Stdout}, {"stderr", out.Stderr}} {
+			text := strings.TrimSuffix(string(s.data), "\n")
+			if text == "" {
+				continue
+			}
+
+			for _, line := range strings.Split(text, "\n") {
+				onLine(s.name, strings.TrimSuffix(line, "\r"))
+			}
+		}
+	}
+
+	return out, err
+}
+
+// Returns the working directory the command runs in, which is the
+// directory of the current process when the command has none.
+func Cwd(cmd *exec.Cmd) string {
+	if cmd.Dir != "" {
+		return cmd.Dir
+	}
+
+	dir, _ := os.Getwd()
+	return dir
+}
@@ -0,0 +1,90 @@
+package proc
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// calls fn for every line written without keeping the lines, the
+// partial last line is kept until the next write or flush. Once fn
+// fails, the rest is discarded and stop is called.
+type scanWriter struct {
+	buf  []byte
+	fn   func(line []byte) error
+	err  error
+	stop func()
+}
+
+func (w *scanWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return len(p), nil
+	}
+
+	w.buf = append(w.buf, p...)
+	for w.err == nil {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.call(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+
+	// move the partial line to the front so the buffer does not grow
+	// with the total output
+	w.buf = append(w.buf[:0], w.buf...)
+	return len(p), nil
+}
+
+func (w *scanWriter) flush() {
+	if w.err == nil && len(w.buf) > 0 {
+		w.call(w.buf)
+	}
+
+	w.buf = nil
+}
+
+func (w *scanWriter) call(line []byte) {
+	if w.err = w.fn(bytes.TrimSuffix(line, []byte{'\r'})); w.err != nil {
+		w.stop()
+	}
+}
+
+// Runs the command and calls fn for every line written to stdout
+// as it is written, without capturing stdout, so the output can be
+// larger than the memory of the process. The line is only valid
+// until fn returns. Stderr is captured. When fn returns an error,
+// the process tree is killed and the error is returned as is.
+func ScanLines(ctx context.Context, cmd *exec.Cmd, fn func(line []byte) error) (*exec.PsOutput, error) {
+	if run := runnerFrom(ctx); run != nil {
+		out, err := run(cmd)
+		if out != nil {
+			w := &scanWriter{fn: fn, stop: func() {}}
+			w.Write(out.Stdout)
+			w.flush()
+			if w.err != nil {
+				return out, w.err
+			}
+		}
+
+		return out, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var errb bytes.Buffer
+	stdout := &scanWriter{fn: fn, stop: cancel}
+	cmd.Stdout = stdout
+	cmd.Stderr = &errb
+	out, err := Wait(ctx, cmd)
+	stdout.flush()
+	out.Stderr = errb.Bytes()
+	if stdout.err != nil {
+		return out, stdout.err
+	}
+
+	return out, err
+}
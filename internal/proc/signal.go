@@ -0,0 +1,63 @@
+package proc
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"slices"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+type forwardSignalsKey struct{}
+
+// Returns a context that makes Wait forward the interrupt and
+// terminate signals received by the current process to the process
+// group of the command while it runs. The command is started in a
+// new process group so that it only receives the signal once, unless
+// its stdin is a terminal, see StdinIsTerminal.
+func WithForwardSignals(ctx context.Context, forward bool) context.Context {
+	if !forward {
+		return ctx
+	}
+
+	return context.WithValue(ctx, forwardSignalsKey{}, true)
+}
+
+func forwardSignalsFrom(ctx context.Context) bool {
+	forward, _ := ctx.Value(forwardSignalsKey{}).(bool)
+	return forward
+}
+
+// relays the forwarded signals to the process group of the started
+// command until the returned func is called. While relaying, the
+// signals no longer terminate the current process. When the command
+// is not in its own process group, the signals the terminal already
+// sends to it are not relayed so that it only receives them once.
+func forwardSignals(cmd *exec.Cmd, group bool) func() {
+	signals := forwardedSignals
+	if !group {
+		signals = slices.DeleteFunc(slices.Clone(signals), func(sig os.Signal) bool {
+			return slices.Contains(terminalSignals, sig)
+		})
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-ch:
+				Signal(cmd, sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
@@ -0,0 +1,87 @@
+package proc
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+type line struct {
+	stream string
+	text   string
+}
+
+// splits the bytes written into lines which are sent to the
+// lines channel, the partial last line is kept until the next
+// write or flush.
+type lineWriter struct {
+	stream  string
+	buf     []byte
+	lines   chan<- line
+	capture *limitWriter
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.capture.Write(p)
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		text := strings.TrimSuffix(string(w.buf[:i]), "\r")
+		w.lines <- line{stream: w.stream, text: text}
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+func (w *lineWriter) flush() {
+	if len(w.buf) > 0 {
+		w.lines <- line{stream: w.stream, text: strings.TrimSuffix(string(w.buf), "\r")}
+		w.buf = nil
+	}
+}
+
+// Runs the command and invokes onLine for every line written to
+// stdout or stderr while still capturing both streams. The stream
+// argument is "stdout" or "stderr". Lines are delivered in order
+// for each stream and onLine is always invoked from a single
+// goroutine. The captured output is limited the same as Output by
+// WithOutputLimit, onLine still receives the lines written until
+// the process tree is killed.
+func Stream(ctx context.Context, cmd *exec.Cmd, onLine func(stream string, line string)) (*exec.PsOutput, error) {
+	if fn := runnerFrom(ctx); fn != nil {
+		return streamRunner(fn, cmd, onLine)
+	}
+
+	lines := make(chan line, 64)
+	done := make(chan struct{})
+	go func() {
+		for l := range lines {
+			onLine(l.stream, l.text)
+		}
+
+		close(done)
+	}()
+
+	w, exceeded, check := limitOutput(ctx, cmd.Path, 2)
+	stdout := &lineWriter{stream: "stdout", lines: lines, capture: w[0]}
+	stderr := &lineWriter{stream: "stderr", lines: lines, capture: w[1]}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	out, err := wait(ctx, cmd, 0, exceeded)
+	stdout.flush()
+	stderr.flush()
+	close(lines)
+	<-done
+
+	out.Stdout = w[0].Bytes()
+	out.Stderr = w[1].Bytes()
+	return out, check(err)
+}
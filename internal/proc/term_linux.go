@@ -0,0 +1,5 @@
+package proc
+
+import "syscall"
+
+const ioctlReadTermios = syscall.TCGETS
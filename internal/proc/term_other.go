@@ -0,0 +1,8 @@
+//go:build !windows && !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package proc
+
+// reports false since terminals are not detected on this platform
+func isTerminal(fd uintptr) bool {
+	return false
+}
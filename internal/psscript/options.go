@@ -0,0 +1,267 @@
+package psscript
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-fs"
+	"github.com/jolt9dev/go-platform"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+	"github.com/jolt9dev/go-spawn/internal/tempfile"
+)
+
+// Host describes what differs between the powershell and
+// winpowershell packages when creating and running commands.
+type Host struct {
+	// The name errors are prefixed with, e.g. powershell.
+	Name string
+
+	// Resolves the executable. When an error is returned, the
+	// command still uses the returned path and the error is
+	// returned when the command is run.
+	Which func() (string, error)
+
+	// Returns -Sta or -Mta for the apartment state, or an empty
+	// string when the executable does not support it.
+	ApartmentFlag func(state string) string
+
+	// Returned when the apartment state is not STA or MTA.
+	ErrApartmentState error
+
+	// Tracks the temp files written for inline scripts.
+	TempFiles *tempfile.Files
+
+	// Returns the func called right before a command starts or nil.
+	BeforeRun func() func(cmd *exec.Cmd)
+
+	// Returns the runner called instead of spawning a process or nil.
+	Runner func() func(cmd *exec.Cmd) (*exec.PsOutput, error)
+}
+
+// Options holds the options shared by the powershell and
+// winpowershell packages, which copy their own options into it
+// to create and run commands.
+type Options struct {
+	StopOnError       bool
+	ErrorAsFailure    bool
+	UseEncodedCommand bool
+	ExecutionPolicy   string
+	ApartmentState    string
+	WindowStyle       string
+	OutputFormat      string
+	OutputEncoding    string
+	NormalizeOutput   bool
+	Profile           bool
+	Debug             bool
+	Dir               string
+	Env               map[string]string
+	ClearEnv          bool
+	TempDir           string
+	KeepTempOnError   bool
+	Timeout           time.Duration
+	MaxOutputBytes    int
+	ForwardSignals    bool
+}
+
+// Creates the command for the script file
+func (o *Options) File(h *Host, file string) *exec.Cmd {
+	return o.Command(h, "-File", file)
+}
+
+// Creates the command for the inline script or file. Scripts with
+// here-strings are written to a temp file when temp is true, which
+// is only done by the functions that remove it after running the
+// command, and are otherwise passed with -EncodedCommand.
+func (o *Options) Script(h *Host, script string, temp bool) *exec.Cmd {
+	if file, ok := File(script); ok {
+		return o.File(h, file)
+	}
+
+	// here-strings are easily mangled when passed as an argument
+	if HasHereString(script) {
+		if temp {
+			return o.ScriptFile(h, script)
+		}
+
+		return o.Encoded(h, o.Inline(script))
+	}
+
+	script = o.Inline(script)
+	if o.UseEncodedCommand && NeedsEncoding(script) {
+		return o.Encoded(h, script)
+	}
+
+	return o.Command(h, "-Command", script)
+}
+
+// Creates the command that writes the inline script to a temp .ps1
+// file tracked by the host and runs it with -File
+func (o *Options) ScriptFile(h *Host, script string) *exec.Cmd {
+	file, err := WriteTemp(o.TempDir, o.Inline(script))
+	if err != nil {
+		cmd := o.Command(h)
+		cmd.Err = err
+		return cmd
+	}
+
+	cmd := o.File(h, file)
+	h.TempFiles.Track(cmd, file)
+	return cmd
+}
+
+// Creates the command for the script passed with -EncodedCommand
+func (o *Options) Encoded(h *Host, script string) *exec.Cmd {
+	return o.Command(h, "-EncodedCommand", Encode(script))
+}
+
+// Creates the command with the flags derived from the options
+// followed by the given arguments
+func (o *Options) Command(h *Host, args ...string) *exec.Cmd {
+	exe, err := h.Which()
+	if err == nil {
+		err = o.checkApartmentState(h)
+	}
+
+	cmd := exec.New(exe, append(o.Flags(h), args...)...)
+	if err != nil {
+		cmd.Err = err
+	}
+
+	if platform.IsWindows() && strings.EqualFold(o.WindowStyle, "Hidden") {
+		proc.HideWindow(cmd)
+	}
+
+	if len(o.Env) > 0 || o.ClearEnv {
+		cmd.Env = proc.MergeEnv(o.Env, o.ClearEnv)
+	}
+
+	o.applyDir(h, cmd)
+	return cmd
+}
+
+// Returns the flags that precede -File or -Command. Profiles are
+// skipped with -NoProfile for reproducible runs unless Profile is
+// set. The execution policy and window style are only passed on
+// Windows.
+func (o *Options) Flags(h *Host) []string {
+	flags := []string{"-NoLogo", "-NoProfile", "-NonInteractive"}
+	if o.Profile {
+		flags = []string{"-NoLogo", "-NonInteractive"}
+	}
+
+	if o.ExecutionPolicy != "" && platform.IsWindows() {
+		flags = append(flags, "-ExecutionPolicy", o.ExecutionPolicy)
+	}
+
+	if o.OutputFormat != "" {
+		flags = append(flags, "-OutputFormat", o.OutputFormat)
+	}
+
+	if flag := h.ApartmentFlag(o.ApartmentState); flag != "" {
+		flags = append(flags, flag)
+	}
+
+	if o.WindowStyle != "" && platform.IsWindows() {
+		flags = append(flags, "-WindowStyle", o.WindowStyle)
+	}
+
+	return flags
+}
+
+// Returns the script with the prelude and epilogue of the options
+func (o *Options) Inline(script string) string {
+	return Inline{
+		StopOnError:    o.StopOnError,
+		ErrorAsFailure: o.ErrorAsFailure,
+		Dir:            o.Dir,
+		OutputEncoding: o.OutputEncoding,
+		Debug:          o.Debug,
+	}.Apply(script)
+}
+
+// Returns the context used to run the command which is limited by
+// the timeout and output limit of the options, forwards signals when
+// enabled and carries the runner and before run func of the host
+func (o *Options) Context(h *Host, ctx context.Context) (context.Context, context.CancelFunc) {
+	if h.Runner != nil {
+		if fn := h.Runner(); fn != nil {
+			ctx = proc.WithRunner(ctx, fn)
+		}
+	}
+
+	if h.BeforeRun != nil {
+		ctx = proc.WithBeforeRun(ctx, h.BeforeRun())
+	}
+
+	ctx = proc.WithOutputLimit(ctx, o.MaxOutputBytes)
+	ctx = proc.WithForwardSignals(ctx, o.ForwardSignals)
+	if o.Timeout > 0 {
+		return context.WithTimeout(ctx, o.Timeout)
+	}
+
+	return ctx, func() {}
+}
+
+// Normalizes the captured output when NormalizeOutput is set
+func (o *Options) Normalize(out *exec.PsOutput) *exec.PsOutput {
+	if out == nil || !o.NormalizeOutput {
+		return out
+	}
+
+	out.Stdout = Normalize(out.Stdout)
+	out.Stderr = Normalize(out.Stderr)
+	return out
+}
+
+// Removes the temp file of a completed command and wraps the error
+// with the path of the temp file
+func (o *Options) Finish(h *Host, cmd *exec.Cmd, err error) error {
+	return h.TempFiles.Finish(cmd, err, o.KeepTempOnError)
+}
+
+// Returns -Sta or -Mta for the apartment state, or an empty string
+// when it is not set or invalid
+func ApartmentFlag(state string) string {
+	switch strings.ToUpper(state) {
+	case "STA":
+		return "-Sta"
+	case "MTA":
+		return "-Mta"
+	default:
+		return ""
+	}
+}
+
+// returns the error of the host when the apartment state is set to
+// an invalid value
+func (o *Options) checkApartmentState(h *Host) error {
+	switch strings.ToUpper(o.ApartmentState) {
+	case "", "STA", "MTA":
+		return nil
+	default:
+		return h.ErrApartmentState
+	}
+}
+
+// sets the working directory of the command and records an error
+// on the command when the directory is invalid
+func (o *Options) applyDir(h *Host, cmd *exec.Cmd) {
+	if o.Dir == "" {
+		return
+	}
+
+	cmd.Dir = o.Dir
+	if cmd.Err != nil {
+		return
+	}
+
+	fi, err := fs.Stat(o.Dir)
+	if err != nil {
+		cmd.Err = fmt.Errorf("%s: working directory %s does not exist: %w", h.Name, o.Dir, err)
+	} else if !fi.IsDir() {
+		cmd.Err = fmt.Errorf("%s: working directory %s is not a directory", h.Name, o.Dir)
+	}
+}
@@ -0,0 +1,132 @@
+package psscript
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+	"github.com/jolt9dev/go-spawn/internal/tempfile"
+)
+
+var errApartment = errors.New("test: the apartment state must be STA or MTA")
+
+func testHost() *Host {
+	return &Host{
+		Name:              "test",
+		Which:             os.Executable,
+		ApartmentFlag:     ApartmentFlag,
+		ErrApartmentState: errApartment,
+		TempFiles:         &tempfile.Files{},
+	}
+}
+
+func TestOptionsScript(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   Options
+		script string
+		temp   bool
+		flag   string
+	}{
+		{"command", Options{}, "Get-Date", false, "-Command"},
+		{"file", Options{}, " build.ps1 ", false, "-File"},
+		{"here-string", Options{}, "@'\na\n'@", false, "-EncodedCommand"},
+		{"here-string temp", Options{}, "@'\na\n'@", true, "-File"},
+		{"encoded", Options{UseEncodedCommand: true}, `Write-Output "a"`, false, "-EncodedCommand"},
+		{"not encoded", Options{}, `Write-Output "a"`, false, "-Command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := testHost()
+			tt.opts.TempDir = t.TempDir()
+			cmd := tt.opts.Script(h, tt.script, tt.temp)
+			defer h.TempFiles.Remove(cmd)
+
+			if !slices.Contains(cmd.Args, tt.flag) {
+				t.Errorf("args = %q, want %s", cmd.Args, tt.flag)
+			}
+
+			entries, err := os.ReadDir(tt.opts.TempDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if wrote := len(entries) > 0; wrote != (tt.name == "here-string temp") {
+				t.Errorf("wrote %d temp files", len(entries))
+			}
+		})
+	}
+}
+
+func TestOptionsCommand(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		err  string
+	}{
+		{"valid", Options{Dir: t.TempDir(), ApartmentState: "sta"}, ""},
+		{"missing dir", Options{Dir: filepath.Join(t.TempDir(), "missing")}, "test: working directory"},
+		{"not a dir", Options{Dir: file}, "is not a directory"},
+		{"apartment", Options{ApartmentState: "both"}, errApartment.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.opts.Command(testHost(), "-Command", "Get-Date")
+			switch {
+			case tt.err == "" && cmd.Err != nil:
+				t.Errorf("cmd.Err = %v", cmd.Err)
+			case tt.err != "" && (cmd.Err == nil || !strings.Contains(cmd.Err.Error(), tt.err)):
+				t.Errorf("cmd.Err = %v, want %q", cmd.Err, tt.err)
+			}
+		})
+	}
+}
+
+func TestOptionsFlags(t *testing.T) {
+	h := testHost()
+	got := (&Options{ApartmentState: "MTA", OutputFormat: "Text"}).Flags(h)
+	want := []string{"-NoLogo", "-NoProfile", "-NonInteractive", "-OutputFormat", "Text", "-Mta"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Flags() = %q, want %q", got, want)
+	}
+
+	if got := (&Options{Profile: true}).Flags(h); slices.Contains(got, "-NoProfile") {
+		t.Errorf("Flags() = %q, want profiles to load", got)
+	}
+}
+
+func TestOptionsContextRunner(t *testing.T) {
+	h := testHost()
+	h.Runner = func() func(cmd *exec.Cmd) (*exec.PsOutput, error) {
+		return func(cmd *exec.Cmd) (*exec.PsOutput, error) {
+			return &exec.PsOutput{Stdout: []byte("a\r\n")}, nil
+		}
+	}
+
+	o := &Options{NormalizeOutput: true}
+	ctx, cancel := o.Context(h, context.Background())
+	defer cancel()
+
+	cmd := o.Script(h, "Get-Date", true)
+	out, err := proc.Output(ctx, cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(o.Normalize(out).Stdout); got != "a\n" {
+		t.Errorf("stdout = %q, want %q", got, "a\n")
+	}
+}
@@ -0,0 +1,243 @@
+// Package psscript contains the handling of inline scripts and
+// script files shared by the powershell and winpowershell packages,
+// such as the prelude of inline scripts, -EncodedCommand and the
+// normalization of captured output.
+package psscript
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/jolt9dev/go-platform"
+	"github.com/jolt9dev/go-spawn/internal/tempfile"
+	"github.com/jolt9dev/go-xstrings"
+)
+
+// characters that do not survive command line quoting
+// intact when passed to -Command
+const mangledChars = "\"\r\n;&|<>^%`"
+
+// matches the start of a here-string which must end the line
+var hereString = regexp.MustCompile(`@["']\r?\n`)
+
+var utf8Bom = []byte{0xEF, 0xBB, 0xBF}
+
+// Inline holds the options that modify the text of an inline
+// script before it is passed to powershell.
+type Inline struct {
+	// Prepends $ErrorActionPreference = 'Stop' and exits with
+	// $LASTEXITCODE after the script.
+	StopOnError bool
+
+	// Exits with the last native exit code or with 1 when any
+	// error was recorded in $Error.
+	ErrorAsFailure bool
+
+	// The directory the script starts in with Set-Location.
+	Dir string
+
+	// The encoding of the console output set before the script.
+	OutputEncoding string
+
+	// Traces each line with Set-PSDebug -Trace 1.
+	Debug bool
+}
+
+// Returns the script with the prelude and epilogue of the options
+func (o Inline) Apply(script string) string {
+	// the provider location can differ from the process working
+	// directory, e.g. when a profile changes it
+	if o.Dir != "" {
+		script = "Set-Location -LiteralPath " + Quote(o.Dir) + "\n" + script
+	}
+
+	if o.OutputEncoding != "" {
+		script = encodingPrefix(o.OutputEncoding) + script
+	}
+
+	if o.Debug {
+		script = "Set-PSDebug -Trace 1\n" + script
+	}
+
+	if o.StopOnError {
+		script = "$ErrorActionPreference = 'Stop'\n" + script
+	}
+
+	// -Command exits with 0 after a failing native command unless
+	// it is the last statement
+	if o.StopOnError || o.ErrorAsFailure {
+		script += "\nif ($LASTEXITCODE) { exit $LASTEXITCODE }"
+	}
+
+	if o.ErrorAsFailure {
+		script += "\nif ($Error.Count -gt 0) { exit 1 }"
+	}
+
+	return script
+}
+
+// Returns the path and true when the script is a single line that
+// names a .ps1 file rather than an inline script
+func File(script string) (string, bool) {
+	if strings.ContainsAny(script, "\n") {
+		return "", false
+	}
+
+	script = strings.TrimSpace(script)
+	return script, xstrings.HasSuffixFold(script, ".ps1")
+}
+
+// Returns the absolute path of a script file relative to dir, or
+// to the current directory when dir is empty. Powershell does not
+// run a script in the current directory by its bare name, and the
+// location of an inline script can differ from the process working
+// directory, so files invoked from inline scripts use the absolute
+// path.
+func AbsFile(dir, file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+
+	if dir != "" {
+		file = filepath.Join(dir, file)
+	}
+
+	if abs, err := filepath.Abs(file); err == nil {
+		return abs
+	}
+
+	return file
+}
+
+// Returns the data without a leading UTF-8 byte order mark
+func TrimBom(data []byte) []byte {
+	return bytes.TrimPrefix(data, utf8Bom)
+}
+
+// Reports whether the script contains a here-string, which is
+// easily mangled when passed as an argument
+func HasHereString(script string) bool {
+	return hereString.MatchString(script)
+}
+
+// Reports whether the script contains characters that are mangled
+// by cmd or CreateProcess quoting when passed to -Command
+func NeedsEncoding(script string) bool {
+	return strings.ContainsAny(script, mangledChars)
+}
+
+// Returns s quoted as a powershell single quoted string. Single
+// quotes, including the typographic quotes powershell also
+// accepts, are escaped by doubling them.
+func Quote(s string) string {
+	sb := strings.Builder{}
+	sb.WriteRune('\'')
+	for _, r := range s {
+		switch r {
+		case '\'', '‘', '’', '‚', '‛':
+			sb.WriteRune(r)
+		}
+
+		sb.WriteRune(r)
+	}
+
+	sb.WriteRune('\'')
+	return sb.String()
+}
+
+// Returns the script encoded as base64 UTF-16LE which is
+// the format expected by -EncodedCommand
+func Encode(script string) string {
+	units := utf16.Encode([]rune(script))
+	data := make([]byte, len(units)*2)
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(data[i*2:], u)
+	}
+
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// Returns the script decoded from the base64 UTF-16LE
+// format used by -EncodedCommand
+func Decode(encoded string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(data[i*2:])
+	}
+
+	return string(utf16.Decode(units)), nil
+}
+
+// Writes the script to a temp .ps1 file in dir with the line
+// endings of the host platform and returns its path. The file
+// starts with a UTF-8 byte order mark, without which Windows
+// PowerShell 5.1 reads it in the ANSI code page.
+func WriteTemp(dir, script string) (string, error) {
+	if platform.IsWindows() {
+		script = tempfile.CRLF(script)
+	} else {
+		script = tempfile.LF(script)
+	}
+
+	return tempfile.Write(dir, "powershell-*.ps1", string(utf8Bom)+script, 0600)
+}
+
+// Decodes UTF-16 text with a byte order mark, removes a UTF-8 byte
+// order mark and replaces CRLF line endings with LF
+func Normalize(data []byte) []byte {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		data = decodeUTF16(data[2:], false)
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		data = decodeUTF16(data[2:], true)
+	default:
+		data = TrimBom(data)
+	}
+
+	if bytes.Contains(data, []byte("\r\n")) {
+		data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	}
+
+	return data
+}
+
+// returns the statements that set the console output encoding
+func encodingPrefix(name string) string {
+	enc := "[System.Text.Encoding]::GetEncoding(" + Quote(name) + ")"
+	switch strings.ToLower(strings.ReplaceAll(name, "-", "")) {
+	case "utf8":
+		enc = "(New-Object System.Text.UTF8Encoding $false)"
+	}
+
+	return "[Console]::OutputEncoding = " + enc + "\n$OutputEncoding = [Console]::OutputEncoding\n"
+}
+
+func decodeUTF16(data []byte, bigEndian bool) []byte {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+		} else {
+			units = append(units, uint16(data[i+1])<<8|uint16(data[i]))
+		}
+	}
+
+	runes := utf16.Decode(units)
+	buf := make([]byte, 0, len(runes))
+	for _, r := range runes {
+		buf = utf8.AppendRune(buf, r)
+	}
+
+	return buf
+}
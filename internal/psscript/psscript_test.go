@@ -0,0 +1,143 @@
+package psscript
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jolt9dev/go-platform"
+)
+
+func TestNeedsEncoding(t *testing.T) {
+	tests := []struct {
+		script string
+		want   bool
+	}{
+		{"Get-Date", false},
+		{"Write-Host 'a b'", false},
+		{"$env:PATH", false},
+		{`Write-Host "a"`, true},
+		{"a\rb", true},
+		{"a\nb", true},
+		{"a;b", true},
+		{"a&b", true},
+		{"a|b", true},
+		{"a<b", true},
+		{"a>b", true},
+		{"a^b", true},
+		{"%PATH%", true},
+		{"a`nb", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.script, func(t *testing.T) {
+			if got := NeedsEncoding(tt.script); got != tt.want {
+				t.Errorf("NeedsEncoding(%q) = %v, want %v", tt.script, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteTempLineEndings(t *testing.T) {
+	want := "\ufeffa\nb\n"
+	if platform.IsWindows() {
+		want = "\ufeffa\r\nb\r\n"
+	}
+
+	for _, script := range []string{"a\nb\n", "a\r\nb\r\n"} {
+		file, err := WriteTemp(t.TempDir(), script)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != want {
+			t.Errorf("WriteTemp(%q) wrote %q, want %q", script, data, want)
+		}
+
+		if !strings.HasSuffix(file, ".ps1") {
+			t.Errorf("file %s does not have the .ps1 extension", file)
+		}
+	}
+
+	// a lone CR is not a line ending
+	file, err := WriteTemp(t.TempDir(), "a\rb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := os.ReadFile(file); err != nil || string(data) != "\ufeffa\rb" {
+		t.Errorf("WriteTemp(%q) wrote %q, %v", "a\rb", data, err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		want string
+	}{
+		{"empty", nil, ""},
+		{"plain", []byte("a\nb\n"), "a\nb\n"},
+		{"utf-8 bom", []byte("\ufeffa\n"), "a\n"},
+		{"bom only at the start", []byte("a\ufeffb"), "a\ufeffb"},
+		{"crlf", []byte("a\r\nb\r\n"), "a\nb\n"},
+		{"lone cr kept", []byte("a\rb"), "a\rb"},
+		{"bom and crlf", []byte("\ufeffa\r\nb"), "a\nb"},
+		{"utf-16le", []byte{0xFF, 0xFE, 'a', 0, '\r', 0, '\n', 0, 0xE9, 0}, "a\né"},
+		{"utf-16be", []byte{0xFE, 0xFF, 0, 'a', 0, '\n', 0, 0xE9}, "a\né"},
+		{"utf-16le surrogate pair", []byte{0xFF, 0xFE, 0x3D, 0xD8, 0x00, 0xDE}, "😀"},
+		{"utf-16le odd length", []byte{0xFF, 0xFE, 'a', 0, 'b'}, "a"},
+		{"utf-16 without bom is not decoded", []byte{'a', 0}, "a\x00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(Normalize(tt.in)); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAbsFile(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	tests := []struct {
+		name string
+		dir  string
+		file string
+		want string
+	}{
+		{"bare name", "", "a.ps1", filepath.Join(cwd, "a.ps1")},
+		{"relative", "", "./scripts/a.ps1", filepath.Join(cwd, "scripts", "a.ps1")},
+		{"dir", dir, "a.ps1", filepath.Join(dir, "a.ps1")},
+		{"parent", dir, "../a.ps1", filepath.Join(filepath.Dir(dir), "a.ps1")},
+		{"absolute", cwd, filepath.Join(dir, "a b.ps1"), filepath.Join(dir, "a b.ps1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AbsFile(tt.dir, tt.file); got != tt.want {
+				t.Errorf("AbsFile(%q, %q) = %q, want %q", tt.dir, tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrimBom(t *testing.T) {
+	for in, want := range map[string]string{"\ufeff{}": "{}", "{}": "{}", "": "", "\ufeff\ufeff": "\ufeff"} {
+		if got := string(TrimBom([]byte(in))); got != want {
+			t.Errorf("TrimBom(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
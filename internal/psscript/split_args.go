@@ -0,0 +1,97 @@
+package psscript
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrUnterminatedQuote = errors.New("powershell: unterminated quote")
+	ErrTrailingEscape    = errors.New("powershell: trailing backtick")
+)
+
+// the characters that follow a backtick in an escape sequence
+var escapes = map[rune]rune{
+	'0': 0,
+	'a': '\a',
+	'b': '\b',
+	'e': 0x1b,
+	'f': '\f',
+	'n': '\n',
+	'r': '\r',
+	't': '\t',
+	'v': '\v',
+}
+
+// Splits the string into arguments using powershell quoting
+// rules, see powershell.SplitArgs
+func SplitArgs(s string) ([]string, error) {
+	args := []string{}
+	token := strings.Builder{}
+	hasToken := false
+	var quote rune
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				if i+1 < len(runes) && runes[i+1] == '\'' {
+					i++
+					token.WriteRune('\'')
+				} else {
+					quote = 0
+				}
+			} else {
+				token.WriteRune(c)
+			}
+		case c == '`' && quote != '\'':
+			if i+1 >= len(runes) {
+				return nil, ErrTrailingEscape
+			}
+
+			i++
+			if e, ok := escapes[runes[i]]; ok {
+				token.WriteRune(e)
+			} else {
+				token.WriteRune(runes[i])
+			}
+
+			hasToken = true
+		case quote == '"':
+			if c == '"' {
+				if i+1 < len(runes) && runes[i+1] == '"' {
+					i++
+					token.WriteRune('"')
+				} else {
+					quote = 0
+				}
+			} else {
+				token.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			hasToken = true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if hasToken {
+				args = append(args, token.String())
+				token.Reset()
+				hasToken = false
+			}
+		default:
+			token.WriteRune(c)
+			hasToken = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, ErrUnterminatedQuote
+	}
+
+	if hasToken {
+		args = append(args, token.String())
+	}
+
+	return args, nil
+}
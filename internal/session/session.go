@@ -0,0 +1,181 @@
+// Package session runs scripts one after another in a long-lived
+// shell process that reads them from stdin. Each script is followed
+// by a marker that the shell prints with the exit code of the script
+// so the output of each script can be told apart.
+package session
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// ErrClosed is returned by Send after the session was closed or the
+// shell process exited.
+var ErrClosed = errors.New("session: closed")
+
+// Wraps the script so that the shell writes an empty line followed
+// by the marker and the exit code of the script to stdout, and the
+// marker on its own line to stderr, once the script completes.
+type Wrap func(script, marker string) string
+
+// Session is a shell process that runs the scripts written to stdin.
+type Session struct {
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	stderr *bufio.Reader
+	marker string
+	wrap   Wrap
+	closed bool
+}
+
+// Starts the command which must read scripts from stdin and returns
+// the session. The stdio of the command is replaced with pipes.
+func Start(cmd *exec.Cmd, wrap Wrap) (*Session, error) {
+	if cmd.Err != nil {
+		return nil, cmd.Err
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	// bypass the go-exec logger and lookup, the path is resolved
+	if err := cmd.Cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewReader(stdout),
+		stderr: bufio.NewReader(stderr),
+		marker: "__SESSION_" + hex.EncodeToString(b) + "__",
+		wrap:   wrap,
+	}, nil
+}
+
+// Runs the script and returns its stdout, stderr and exit code. The
+// scripts run one at a time, concurrent calls wait for their turn.
+// When the shell exits, e.g. because the script called exit, the
+// session is closed and ErrClosed is returned.
+func (s *Session) Send(script string) (stdout string, stderr string, code int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return "", "", 1, ErrClosed
+	}
+
+	if _, err := io.WriteString(s.stdin, s.wrap(script, s.marker)+"\n"); err != nil {
+		s.close()
+		return "", "", 1, fmt.Errorf("%w: %w", ErrClosed, err)
+	}
+
+	errc := make(chan string, 1)
+	go func() {
+		text, _ := readUntil(s.stderr, func(line string) bool { return line == s.marker })
+		errc <- text
+	}()
+
+	var last string
+	stdout, err = readUntil(s.stdout, func(line string) bool {
+		if strings.HasPrefix(line, s.marker+" ") {
+			last = line
+			return true
+		}
+
+		return false
+	})
+
+	if err != nil {
+		s.close()
+		return stdout, "", 1, fmt.Errorf("%w: %w", ErrClosed, err)
+	}
+
+	stderr = <-errc
+
+	// the wrapper writes a new line before the marker so that it
+	// starts a line even when the output does not end with one
+	if strings.HasSuffix(stdout, "\r\n") {
+		stdout = strings.TrimSuffix(stdout, "\r\n")
+	} else {
+		stdout = strings.TrimSuffix(stdout, "\n")
+	}
+	code, _ = strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(last, s.marker)))
+	return stdout, stderr, code, nil
+}
+
+// Closes stdin so that the shell exits and waits for it. The process
+// is killed when it does not exit within a few seconds.
+func (s *Session) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+
+	return s.close()
+}
+
+func (s *Session) close() error {
+	s.closed = true
+	s.stdin.Close()
+	done := make(chan error, 1)
+	go func() {
+		done <- s.cmd.Cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		s.cmd.Process.Kill()
+		return <-done
+	}
+}
+
+// reads lines until done reports true for a line, which is not
+// included, and returns the text read before it
+func readUntil(r *bufio.Reader, done func(line string) bool) (string, error) {
+	var sb strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			sb.WriteString(line)
+			return sb.String(), err
+		}
+
+		if done(strings.TrimRight(line, "\r\n")) {
+			return sb.String(), nil
+		}
+
+		sb.WriteString(line)
+	}
+}
@@ -0,0 +1,106 @@
+// Package tempfile writes inline scripts to temp files and tracks
+// them so the shell packages can remove them once the command
+// that runs them completes.
+package tempfile
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Files tracks the temp files created for commands.
+type Files struct {
+	m sync.Map
+}
+
+// Tracks the temp file created for the command
+func (f *Files) Track(cmd *exec.Cmd, file string) {
+	f.m.Store(cmd, file)
+}
+
+// Removes the temp file tracked for the command, if any
+func (f *Files) Remove(cmd *exec.Cmd) error {
+	file, ok := f.m.LoadAndDelete(cmd)
+	if !ok {
+		return nil
+	}
+
+	err := os.Remove(file.(string))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// Removes the temp file tracked for the command once it has
+// completed with err. When err is not nil, it is wrapped with
+// the path of the temp file so the failing script can be found,
+// and the file is kept on disk when keep is true.
+func (f *Files) Finish(cmd *exec.Cmd, err error, keep bool) error {
+	file, ok := f.m.LoadAndDelete(cmd)
+	if !ok {
+		return err
+	}
+
+	path := file.(string)
+	if err == nil {
+		os.Remove(path)
+		return nil
+	}
+
+	if keep {
+		return fmt.Errorf("%w (temp script kept at %s)", err, path)
+	}
+
+	os.Remove(path)
+	return fmt.Errorf("%w (temp script %s)", err, path)
+}
+
+// Writes the content to a new temp file in dir, or the default
+// temp directory when dir is empty, using the pattern from
+// os.CreateTemp and returns the path of the file.
+func Write(dir, pattern, content string, perm os.FileMode) (string, error) {
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		if dir == "" {
+			dir = os.TempDir()
+		}
+
+		return "", fmt.Errorf("temp directory %s is not writable: %w", dir, err)
+	}
+
+	_, err = f.WriteString(content)
+	if err == nil {
+		err = f.Chmod(perm)
+	}
+
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// Returns the content with CRLF line endings replaced by LF, which
+// POSIX shells require since a trailing CR becomes part of the last
+// word, e.g. of the shebang. A lone CR is not a line ending and is
+// kept, since scripts use it in strings such as progress output.
+func LF(content string) string {
+	return strings.ReplaceAll(content, "\r\n", "\n")
+}
+
+// Returns the content with every LF and CRLF line ending replaced
+// by CRLF. A lone CR is kept the same as with LF.
+func CRLF(content string) string {
+	return strings.ReplaceAll(LF(content), "\n", "\r\n")
+}
@@ -0,0 +1,74 @@
+package tempfile
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLineEndings(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		lf   string
+		crlf string
+	}{
+		{"empty", "", "", ""},
+		{"lf", "a\nb\n", "a\nb\n", "a\r\nb\r\n"},
+		{"crlf", "a\r\nb\r\n", "a\nb\n", "a\r\nb\r\n"},
+		{"lone cr", "a\rb\r", "a\rb\r", "a\rb\r"},
+		{"cr in string", "printf 'a\rb'\r\n", "printf 'a\rb'\n", "printf 'a\rb'\r\n"},
+		{"mixed", "a\r\nb\nc\rd", "a\nb\nc\rd", "a\r\nb\r\nc\rd"},
+		{"cr before crlf", "a\r\r\n", "a\r\n", "a\r\r\n"},
+		{"blank lines", "a\r\n\r\nb", "a\n\nb", "a\r\n\r\nb"},
+		{"no line ending", "abc", "abc", "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LF(tt.in); got != tt.lf {
+				t.Errorf("LF(%q) = %q, want %q", tt.in, got, tt.lf)
+			}
+
+			if got := CRLF(tt.in); got != tt.crlf {
+				t.Errorf("CRLF(%q) = %q, want %q", tt.in, got, tt.crlf)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	file, err := Write(dir, "script-*.sh", "echo a\n", 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if filepath.Dir(file) != dir || !strings.HasPrefix(filepath.Base(file), "script-") || !strings.HasSuffix(file, ".sh") {
+		t.Errorf("file %s does not match the pattern in %s", file, dir)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "echo a\n" {
+		t.Errorf("content = %q", data)
+	}
+
+	if fi, err := os.Stat(file); err != nil {
+		t.Fatal(err)
+	} else if runtime.GOOS != "windows" && fi.Mode().Perm() != 0700 {
+		t.Errorf("mode = %v, want 0700", fi.Mode().Perm())
+	}
+}
+
+func TestWriteMissingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	if _, err := Write(dir, "script-*.sh", "", 0600); err == nil || !strings.Contains(err.Error(), dir) {
+		t.Errorf("err = %v, want an error naming %s", err, dir)
+	}
+}
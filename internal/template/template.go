@@ -0,0 +1,67 @@
+// Package template expands ${name} references in script text from
+// a map before the script reaches the shell.
+package template
+
+import "strings"
+
+// Returns the script with each ${name} reference replaced by the
+// value of name in vars. References to names that are not in vars
+// and other forms such as $name or ${name:-default} are left for
+// the shell. $${name} is written as the literal ${name} without
+// being expanded. Values are inserted as is and are not expanded
+// again.
+func Expand(script string, vars map[string]string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(script, "${")
+		if i < 0 {
+			b.WriteString(script)
+			return b.String()
+		}
+
+		end := strings.IndexByte(script[i+2:], '}')
+		if end < 0 {
+			b.WriteString(script)
+			return b.String()
+		}
+
+		name := script[i+2 : i+2+end]
+		next := script[i+3+end:]
+
+		// $${name} escapes the reference
+		if i > 0 && script[i-1] == '$' {
+			b.WriteString(script[:i-1])
+			b.WriteString(script[i : i+3+end])
+			script = next
+			continue
+		}
+
+		b.WriteString(script[:i])
+		if v, ok := vars[name]; ok && isName(name) {
+			b.WriteString(v)
+		} else {
+			b.WriteString(script[i : i+3+end])
+		}
+
+		script = next
+	}
+}
+
+// reports whether s is a variable name made of letters, digits
+// and underscores that does not start with a digit
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, c := range s {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,57 @@
+package template
+
+import "testing"
+
+func TestExpand(t *testing.T) {
+	vars := map[string]string{
+		"name":  "world",
+		"dir":   "/tmp/a b",
+		"_x1":   "x",
+		"other": "${name}",
+		"empty": "",
+		"1a":    "digit",
+		"env:X": "env",
+	}
+
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"no references", "echo hello", "echo hello"},
+		{"reference", "echo ${name}", "echo world"},
+		{"several", "${name} ${dir} ${name}", "world /tmp/a b world"},
+		{"adjacent", "${name}${name}", "worldworld"},
+		{"underscore and digits", "${_x1}", "x"},
+		{"empty value", "a${empty}b", "ab"},
+		{"missing key", "echo ${missing} ${name}", "echo ${missing} world"},
+		{"plain dollar", "echo $name", "echo $name"},
+		{"default form", "echo ${name:-x}", "echo ${name:-x}"},
+		{"invalid name", "${1a} ${env:X}", "${1a} ${env:X}"},
+		{"empty name", "${}", "${}"},
+		{"escape", "echo $${name}", "echo ${name}"},
+		{"escape then reference", "$${name} ${name}", "${name} world"},
+		{"escape missing key", "$${missing}", "${missing}"},
+		{"values are not expanded again", "${other}", "${name}"},
+		{"nested braces", "${name:-${name}}", "${name:-${name}}"},
+		{"nested braces then reference", "${a:-${b}} ${name}", "${a:-${b}} world"},
+		{"braces in value position", "{${name}}", "{world}"},
+		{"unterminated", "echo ${name", "echo ${name"},
+		{"unterminated after reference", "${name} ${name", "world ${name"},
+		{"multiline", "a=${name}\necho \"$a\"", "a=world\necho \"$a\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Expand(tt.script, vars); got != tt.want {
+				t.Errorf("Expand(%q) = %q, want %q", tt.script, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandNilVars(t *testing.T) {
+	if got := Expand("echo ${name} $${name}", nil); got != "echo ${name} ${name}" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,48 @@
+// Package version parses and caches the versions reported by
+// shell executables.
+package version
+
+import (
+	"regexp"
+	"sync"
+)
+
+var pattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// Returns the first dotted version number in the text such as
+// 5.2.21 from "GNU bash, version 5.2.21(1)-release" and whether
+// one was found.
+func Parse(text string) (string, bool) {
+	v := pattern.FindString(text)
+	return v, v != ""
+}
+
+// Cache holds the versions resolved per executable path. Only
+// successful results are cached so that a failed probe is
+// retried on the next call.
+type Cache struct {
+	mu sync.Mutex
+	m  map[string]string
+}
+
+// Returns the cached version for the executable or calls fn to
+// resolve it.
+func (c *Cache) Get(exe string, fn func() (string, error)) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.m[exe]; ok {
+		return v, nil
+	}
+
+	v, err := fn()
+	if err != nil {
+		return "", err
+	}
+
+	if c.m == nil {
+		c.m = map[string]string{}
+	}
+
+	c.m[exe] = v
+	return v, nil
+}
@@ -0,0 +1,15 @@
+// Package all registers every shell package with the shells
+// registry when imported.
+package all
+
+import (
+	_ "github.com/jolt9dev/go-spawn/shells/bash"
+	_ "github.com/jolt9dev/go-spawn/shells/cmd"
+	_ "github.com/jolt9dev/go-spawn/shells/dash"
+	_ "github.com/jolt9dev/go-spawn/shells/fish"
+	_ "github.com/jolt9dev/go-spawn/shells/ksh"
+	_ "github.com/jolt9dev/go-spawn/shells/powershell"
+	_ "github.com/jolt9dev/go-spawn/shells/sh"
+	_ "github.com/jolt9dev/go-spawn/shells/winpowershell"
+	_ "github.com/jolt9dev/go-spawn/shells/zsh"
+)
@@ -0,0 +1,93 @@
+package all
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jolt9dev/go-spawn/shells"
+	"github.com/jolt9dev/go-spawn/shells/bash"
+	"github.com/jolt9dev/go-spawn/shells/cmd"
+	"github.com/jolt9dev/go-spawn/shells/dash"
+	"github.com/jolt9dev/go-spawn/shells/fish"
+	"github.com/jolt9dev/go-spawn/shells/ksh"
+	"github.com/jolt9dev/go-spawn/shells/powershell"
+	"github.com/jolt9dev/go-spawn/shells/sh"
+	"github.com/jolt9dev/go-spawn/shells/zsh"
+)
+
+func TestRunAllExitCode(t *testing.T) {
+	names := []string{"sh", "bash", "dash", "zsh", "ksh", "fish"}
+	results := shells.RunAll("exit 5", names...)
+
+	ran := 0
+	for _, name := range names {
+		r := results[name]
+		if r == nil {
+			t.Fatalf("%s: missing result", name)
+		}
+
+		if r.Skipped {
+			continue
+		}
+
+		ran++
+		if r.Output == nil || r.Output.Code != 5 {
+			t.Errorf("%s: out = %+v, want code 5", name, r.Output)
+		}
+
+		if r.Err == nil {
+			t.Errorf("%s: expected an error for a non-zero exit", name)
+		}
+	}
+
+	if ran == 0 {
+		t.Skip("no posix shell found")
+	}
+}
+
+func TestWhichFromEnv(t *testing.T) {
+	tests := []struct {
+		variable string
+		which    func() string
+	}{
+		{"BASH_PATH", bash.WhichFromEnv},
+		{"CMD_PATH", cmd.WhichFromEnv},
+		{"DASH_PATH", dash.WhichFromEnv},
+		{"FISH_PATH", fish.WhichFromEnv},
+		{"KSH_PATH", ksh.WhichFromEnv},
+		{"PWSH_PATH", powershell.WhichFromEnv},
+		{"SH_PATH", sh.WhichFromEnv},
+		{"BUSYBOX_PATH", sh.WhichFromEnv},
+		{"ZSH_PATH", zsh.WhichFromEnv},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variable, func(t *testing.T) {
+			home := filepath.Join(t.TempDir(), "my home")
+			if err := os.Mkdir(home, 0700); err != nil {
+				t.Fatal(err)
+			}
+
+			exe := filepath.Join(home, "shell")
+			if err := os.WriteFile(exe, []byte("#!/bin/sh\n"), 0700); err != nil {
+				t.Fatal(err)
+			}
+
+			t.Setenv("HOME", home)
+			t.Setenv("SH_PATH", "")
+			t.Setenv("BUSYBOX_PATH", "")
+			for _, value := range []string{exe, `"` + exe + `"`, "${HOME}/shell", `"${HOME}/shell"`} {
+				t.Setenv(tt.variable, value)
+				if got := tt.which(); filepath.Clean(got) != exe {
+					t.Errorf("%s=%s: WhichFromEnv() = %q, want %q", tt.variable, value, got, exe)
+				}
+			}
+
+			t.Setenv(tt.variable, filepath.Join(home, "missing"))
+			if got := tt.which(); got != "" {
+				t.Errorf("%s set to a missing file: WhichFromEnv() = %q, want empty", tt.variable, got)
+			}
+		})
+	}
+}
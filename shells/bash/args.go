@@ -0,0 +1,81 @@
+package bash
+
+import "github.com/jolt9dev/go-exec"
+
+// Returns the resolved executable and the full argument vector
+// that Script would run for the inline script or file without
+// running anything, which is useful for logging or testing how
+// a command is constructed. No temp file is written, so the
+// arguments stay valid after ScriptArgs returns.
+//
+// Example:
+//
+//	exe, args := bash.ScriptArgs("echo hello")
+//	log.Println(exe, args) // /usr/bin/bash [--noprofile --norc -e -o pipefail -c echo hello]
+func ScriptArgs(script string) (path string, args []string) {
+	return NewOptions().ScriptArgs(script)
+}
+
+// Returns the resolved executable and the full argument vector
+// for the inline script or file using the options
+func (o *Options) ScriptArgs(script string) (path string, args []string) {
+	cmd := o.Script(script)
+	return cmd.Path, append([]string{}, cmd.Args[1:]...)
+}
+
+// Creates a new bash command with the given inline script or file
+// and the positional arguments passed to the script as $1, $2 and
+// so on. Inline scripts run with bash -c script -- args so the
+// arguments are never interpreted by bash. Arguments that are
+// absolute windows paths are translated when bash runs in WSL
+// unless TranslateArgs is false.
+//
+// Example:
+//
+//	bash.ScriptWithArgs(`echo "hello $1"`, "world").Output()
+func ScriptWithArgs(script string, args ...string) *exec.Cmd {
+	return NewOptions().ScriptWithArgs(script, args...)
+}
+
+// Creates a new bash command with the given script file followed
+// by the positional arguments passed to the script. Arguments are
+// translated the same as ScriptWithArgs.
+//
+// Example:
+//
+//	bash.FileWithArgs("deploy.sh", "--env", "staging").Run()
+func FileWithArgs(file string, args ...string) *exec.Cmd {
+	return NewOptions().FileWithArgs(file, args...)
+}
+
+// translates the positional arguments when bash runs in WSL
+func (o *Options) scriptArgs(args []string) []string {
+	if TranslateArgs && o.useWsl() {
+		return translateArgs(args)
+	}
+
+	return args
+}
+
+// Creates a new bash command with the given inline script or file
+// and the files passed as the positional arguments, so the script
+// can iterate them with "$@" without word splitting or globbing.
+// File names with spaces, quotes or newlines arrive intact, unlike
+// the output of $(ls) or find. Names may still start with a dash,
+// so pass them to commands after --. Absolute windows paths are
+// translated the same as ScriptWithArgs. The number of files is
+// limited by the maximum command line length of the os.
+//
+// Example:
+//
+//	files, _ := filepath.Glob("reports/*.csv")
+//	bash.ScriptOverFiles(`for f in "$@"; do wc -l -- "$f"; done`, files).Run()
+func ScriptOverFiles(script string, files []string) *exec.Cmd {
+	return NewOptions().ScriptOverFiles(script, files)
+}
+
+// Creates a new bash command with the given inline script or file
+// and the files passed as the positional arguments
+func (o *Options) ScriptOverFiles(script string, files []string) *exec.Cmd {
+	return o.ScriptWithArgs(script, files...)
+}
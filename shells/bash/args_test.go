@@ -0,0 +1,125 @@
+package bash
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+func TestScriptWithArgsArgv(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"script", ScriptWithArgs("echo", "a b", "-e").Args, []string{"-c", "echo", "--", "a b", "-e"}},
+		{"script without args", ScriptWithArgs("echo").Args, []string{"-c", "echo"}},
+		{"named script", NewOptions().WithName("job").ScriptWithArgs("echo", "a").Args, []string{"-c", "echo", "job", "a"}},
+		{"file", FileWithArgs("run.sh", "a b", "--env").Args, []string{"run.sh", "a b", "--env"}},
+		{"script file", ScriptWithArgs("run.sh", "a").Args, []string{"run.sh", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.args) < len(tt.want) || !slices.Equal(tt.args[len(tt.args)-len(tt.want):], tt.want) {
+				t.Errorf("args %q do not end with %q", tt.args, tt.want)
+			}
+		})
+	}
+}
+
+func TestScriptWithArgs(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	args := []string{"a b", "-e", "", "$HOME", "*", "it's", "a\nb", "--"}
+	want := strings.Join(args, "\x00") + "\x00"
+	script := `printf '%s\0' "$@"`
+
+	file := filepath.Join(t.TempDir(), "args.sh")
+	if err := os.WriteFile(file, []byte(script+"\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		cmd  *exec.Cmd
+	}{
+		{"script", ScriptWithArgs(script, args...)},
+		{"named script", NewOptions().WithName("job").ScriptWithArgs(script, args...)},
+		{"file", FileWithArgs(file, args...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.cmd.Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(out.Stdout) != want {
+				t.Errorf("got %q, want %q", out.Stdout, want)
+			}
+		})
+	}
+}
+
+func TestScriptOverFiles(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	dir := t.TempDir()
+	names := []string{"plain.txt", "with space.txt", "it's.txt", `say "hi".txt`, "$HOME.txt", "*.txt", "-n", "new\nline.txt", "semi;colon.txt", "back`tick`.txt"}
+	files := make([]string, len(names))
+	for i, name := range names {
+		files[i] = filepath.Join(dir, name)
+		if err := os.WriteFile(files[i], []byte(name), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"count", `echo $#`, strconv.Itoa(len(files)) + "\n"},
+		{"names", `printf '%s\0' "$@"`, strings.Join(files, "\x00") + "\x00"},
+		{"contents", `for f in "$@"; do cat -- "$f"; printf '\0'; done`, strings.Join(names, "\x00") + "\x00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := ScriptOverFiles(tt.script, files).Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(out.Stdout) != tt.want {
+				t.Errorf("got %q, want %q", out.Stdout, tt.want)
+			}
+		})
+	}
+}
+
+func TestScriptOverFilesEmpty(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	out, err := ScriptOverFiles(`echo $#`, nil).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out.Stdout) != "0\n" {
+		t.Errorf("got %q, want no arguments", out.Stdout)
+	}
+}
@@ -0,0 +1,125 @@
+package bash
+
+import (
+	"path/filepath"
+
+	"github.com/jolt9dev/go-env"
+	"github.com/jolt9dev/go-fs"
+	"github.com/jolt9dev/go-platform"
+	"github.com/jolt9dev/go-spawn/internal/busybox"
+	"github.com/jolt9dev/go-xstrings"
+)
+
+// BackendType identifies the kind of bash that runs scripts.
+type BackendType string
+
+const (
+	// bash installed natively on the host such as on linux,
+	// macOS, msys2 or cygwin.
+	Native BackendType = "native"
+
+	// bash installed with Git for Windows.
+	GitBash BackendType = "git-bash"
+
+	// bash running inside the Windows Subsystem for Linux
+	// using System32\bash.exe or wsl.exe.
+	Wsl BackendType = "wsl"
+)
+
+// When true, Git-Bash is used on Windows when both Git-Bash and
+// the WSL System32\bash.exe are installed. When false, the WSL bash
+// is preferred. BASH_PATH always takes precedence. Call
+// ResetWhichCache after changing it.
+var PreferGitBash = true
+
+var gitBashPaths = []string{
+	"${ProgramFiles}\\Git\\bin\\bash.exe",
+	"${ProgramFiles}\\Git\\usr\\bin\\bash.exe",
+	"${ProgramFiles(x86)}\\Git\\bin\\bash.exe",
+	"${ProgramFiles(x86)}\\Git\\usr\\bin\\bash.exe",
+}
+
+// Returns the kind of bash that runs scripts by default, which
+// determines whether windows paths are translated for WSL.
+//
+// Example:
+//
+//	if bash.Backend() == bash.Wsl {
+//		log.Println("bash runs inside WSL, paths are translated to /mnt")
+//	}
+func Backend() BackendType {
+	if !platform.IsWindows() {
+		return Native
+	}
+
+	if NewOptions().useWsl() {
+		return Wsl
+	}
+
+	if xstrings.ContainsFold(WhichOrDefault(), "\\Git\\") {
+		return GitBash
+	}
+
+	return Native
+}
+
+// Reports whether the resolved bash is busybox ash, e.g. bash
+// linked to busybox in a minimal container image. The options
+// that busybox does not support, such as --norc and, for older
+// versions, -o pipefail, are omitted from commands created with
+// Options. The result is cached per executable.
+//
+// Example:
+//
+//	if bash.IsBusybox() {
+//		log.Println("bash is busybox ash, arrays are not available")
+//	}
+func IsBusybox() bool {
+	return busybox.Is(Which())
+}
+
+// probes the windows locations in the preferred order since the
+// System32 bash.exe is usually on the PATH ahead of Git-Bash.
+func findPreferred() string {
+	if !platform.IsWindows() || env.Get("BASH_PATH") != "" {
+		return ""
+	}
+
+	if !PreferGitBash {
+		if p := WslBashPath(); p != "" {
+			return p
+		}
+	}
+
+	for _, p := range gitBashPaths {
+		p, _ = env.Expand(p)
+		if p != "" && fs.IsFile(p) {
+			return p
+		}
+	}
+
+	return WslBashPath()
+}
+
+// Returns the path to the WSL System32\bash.exe or an empty
+// string when WSL or its bash.exe is not installed. Commands run
+// with this bash have windows paths translated to /mnt paths.
+//
+// Example:
+//
+//	if bash.Which() == bash.WslBashPath() {
+//		log.Println("only the WSL bash is available")
+//	}
+func WslBashPath() string {
+	installed, exe := detectWsl()
+	if !installed {
+		return ""
+	}
+
+	p := filepath.Join(filepath.Dir(exe), "bash.exe")
+	if fs.IsFile(p) {
+		return p
+	}
+
+	return ""
+}
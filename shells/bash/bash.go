@@ -1,60 +1,95 @@
 package bash
 
 import (
-	"path/filepath"
-	"strings"
-	"unicode"
+	osexec "os/exec"
+	"sync"
 
-	"github.com/jolt9dev/go-env"
 	"github.com/jolt9dev/go-exec"
-	"github.com/jolt9dev/go-fs"
-	"github.com/jolt9dev/go-platform"
-	"github.com/jolt9dev/go-xstrings"
+	"github.com/jolt9dev/go-spawn/internal/lookup"
 )
 
-var wslInstalled = false
+var whichCache struct {
+	sync.Mutex
+	path     string
+	override string
+}
 
 func init() {
 	exec.Register("bash", &exec.Executable{
 		Name:     "bash",
 		Variable: "BASH_PATH",
-		Windows: []string{
-			"${ProgramFiles}\\Git\\bin\\bash.exe",
-			"${ProgramFiles}\\Git\\usr\\bin\\bash.exe",
-			"${ProgramFiles(x86)}\\Git\\bin\\bash.exe",
-			"${ProgramFiles(x86)}\\Git\\usr\\bin\\bash.exe",
+		Windows: append(append([]string{}, gitBashPaths...),
 			"${SystemRoot}\\System32\\bash.exe",
-		},
+		),
 		Linux: []string{
 			"/bin/bash",
 			"/usr/bin/bash",
 		},
 	})
 
-	if platform.IsWindows() {
-		drive := env.Get("SystemRoot")
-		if drive == "" {
-			drive = "C:\\Windows"
-		}
+	detectWsl()
+}
 
-		fp := filepath.Join(drive, "System32", "wsl.exe")
+// Returns the path to the bash executable or an empty string.
+// The path is resolved in order from SetBashPath, then BASH_PATH,
+// which may be quoted and contain variables such as ${HOME}, then
+// the known locations and then the PATH. WhichOrDefault falls back
+// to the bare name. The resolved path is cached until
+// ResetWhichCache is called.
+func Which() string {
+	whichCache.Lock()
+	defer whichCache.Unlock()
 
-		fi, err := fs.Stat(fp)
-		wslInstalled = err == nil && !fi.IsDir()
+	if whichCache.override != "" {
+		return whichCache.override
 	}
-}
 
-// Returns the path to the bash executable or an empty string
-func Which() string {
-	exe, _ := exec.Find("bash")
+	if whichCache.path != "" {
+		return whichCache.path
+	}
+
+	exe := WhichFromEnv()
+	if exe == "" {
+		exe = findPreferred()
+	}
+
+	if exe == "" {
+		exe, _ = exec.Find("bash")
+	}
+
+	if exe == "" {
+		exe, _ = osexec.LookPath("bash")
+	}
+
+	whichCache.path = exe
 	return exe
 }
 
+// Returns the unquoted and expanded path from BASH_PATH when it
+// is an existing file, otherwise an empty string.
+//
+// Example:
+//
+//	if bash.WhichFromEnv() == "" {
+//		log.Println("BASH_PATH is not set, probing for bash")
+//	}
+func WhichFromEnv() string {
+	return lookup.FromEnv("BASH_PATH")
+}
+
+// Clears the cached path resolved by Which so that the next
+// call probes the file system again, e.g. after PATH changes.
+func ResetWhichCache() {
+	whichCache.Lock()
+	defer whichCache.Unlock()
+	whichCache.path = ""
+}
+
 // Returns the path to the bash executable or the default
 // which is the name of the executable without a path or
 // extension.
 func WhichOrDefault() string {
-	exe, _ := exec.Find("bash")
+	exe := Which()
 	if exe == "" {
 		return "bash"
 	}
@@ -63,23 +98,80 @@ func WhichOrDefault() string {
 }
 
 // Creates a new bash command with the given arguments
-// using vardiac arguments
+// using vardiac arguments. When bash runs inside WSL, absolute
+// windows paths are translated unless TranslateArgs is false.
+// See StrictArgs to catch scripts passed as the first argument.
 //
 // Example:
 //
 //	bash.New("--norc", "-e", "-o", "pipefail", "-c", "echo hello").Run()
 func New(args ...string) *exec.Cmd {
-	return exec.New(WhichOrDefault(), args...)
+	if TranslateArgs && NewOptions().useWsl() {
+		args = translateArgs(args)
+	}
+
+	return newCmd(WhichOrDefault(), args)
+}
+
+// Sets the path of the bash executable used by every command,
+// which takes precedence over the locations probed by Which.
+// WSL path translation is applied based on the override. Pass
+// an empty string to remove the override.
+//
+// Example:
+//
+//	bash.SetBashPath("/opt/bash-5.2/bin/bash")
+func SetBashPath(path string) {
+	whichCache.Lock()
+	defer whichCache.Unlock()
+	whichCache.override = lookup.Unquote(path)
+}
+
+// Creates a new bash command using the given bash executable
+// instead of the resolved one with the given arguments using
+// vardiac arguments.
+//
+// Example:
+//
+//	bash.NewWithExe("/usr/local/bin/bash", "--norc", "-c", "echo hello").Run()
+func NewWithExe(exe string, args ...string) *exec.Cmd {
+	if TranslateArgs && isWslBash(exe) {
+		args = translateArgs(args)
+	}
+
+	return newCmd(exe, args)
 }
 
 // Creates a new bash command with the given arguments
-// using a single string
+// using a single string that is split with SplitArgs.
+// Arguments are translated the same as New. When the
+// string cannot be split, the error is returned when
+// the command is run.
 //
 // Example:
 //
 //	bash.Command("--norc -e -o pipefail -c 'echo hello'").Run()
 func Command(args string) *exec.Cmd {
-	return exec.New(WhichOrDefault(), exec.SplitArgs(args)...)
+	split, err := SplitArgs(args)
+	if err != nil {
+		cmd := New()
+		cmd.Err = err
+		return cmd
+	}
+
+	return New(split...)
+}
+
+// Creates a new bash command with the given arguments that are
+// already split, so values with embedded spaces are passed as is.
+// Arguments are translated the same as New.
+//
+// Example:
+//
+//	exe, args := bash.ScriptArgs("echo hello")
+//	bash.CommandArgs(args).Run()
+func CommandArgs(args []string) *exec.Cmd {
+	return New(args...)
 }
 
 // Creates a new bash command with the given script file
@@ -88,27 +180,17 @@ func Command(args string) *exec.Cmd {
 //
 //	bash.File("script.sh").Run()
 func File(file string) *exec.Cmd {
-	args := []string{"-noprofile", "--norc", "-e", "-o", "pipefail"}
-	exe := WhichOrDefault()
-	if wslInstalled {
-		if xstrings.HasSuffixFold("System32\\bash.exe", exe) {
-			f, err := filepath.Abs(file)
-			if err == nil {
-				file = f
-			}
-
-			file = "/mnt/" + string(unicode.ToLower(rune(file[0]))) + file[2:]
-			file = filepath.ToSlash(file)
-		}
-	}
-
-	args = append(args, file)
-	return exec.New(exe, args...)
+	return NewOptions().File(file)
 }
 
 // Creates a new bash command with the given inline script
 // or file. However, the file must have a .sh extension
-// and be on a single line.
+// and be on a single line. The script is always passed
+// with -c, so the command leaves nothing behind when run
+// directly. Run, Output and the other functions of the
+// package write scripts larger than ScriptFileThreshold
+// to a temp file instead, which they remove once the
+// command completes.
 //
 // Example:
 //
@@ -117,23 +199,14 @@ func File(file string) *exec.Cmd {
 //	  zip`).WithCwd("/path/to/dir").Run()
 //	bash.Script("/path/to/script.sh").Output()
 func Script(script string) *exec.Cmd {
-	if !strings.ContainsAny(script, "\n") {
-		script = strings.TrimSpace(script)
-
-		if strings.HasSuffix(script, ".sh") {
-			return File(script)
-		}
-	}
-
-	args := []string{"-noprofile", "--norc", "-e", "-o", "pipefail", "-c", script}
-	return exec.New(WhichOrDefault(), args...)
+	return NewOptions().Script(script)
 }
 
 // Run a new bash inline script or file.
 // When using a file, the file must have a .sh extension
 // and be on a single line.
 // Run will set stdout and stderr to inherit and not
-// capture the output.
+// capture the output, unless DefaultRunMode is changed.
 //
 // Example:
 //
@@ -142,7 +215,7 @@ func Script(script string) *exec.Cmd {
 //	  zip`).Run()
 //	bash.Run("/path/to/script.sh")
 func Run(script string) (*exec.PsOutput, error) {
-	return Script(script).Run()
+	return NewOptions().Run(script)
 }
 
 // Output a new bash inline script or file.
@@ -161,5 +234,5 @@ func Run(script string) (*exec.PsOutput, error) {
 //	 // handle error
 //	 }
 func Output(script string) (*exec.PsOutput, error) {
-	return Script(script).Output()
+	return NewOptions().Output(script)
 }
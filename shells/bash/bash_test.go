@@ -0,0 +1,61 @@
+package bash
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFileFlags(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	if IsBusybox() {
+		t.Skip("busybox ash does not support the long options")
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"file", File("script.sh").Args},
+		{"script file", Script("script.sh").Args},
+		{"inline", Script("echo a").Args},
+		{"options file", NewOptions().File("script.sh").Args},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, flag := range []string{"--noprofile", "--norc"} {
+				if !slices.Contains(tt.args, flag) {
+					t.Errorf("args %v do not contain %s", tt.args, flag)
+				}
+			}
+
+			if slices.Contains(tt.args, "-noprofile") {
+				t.Errorf("args %v contain -noprofile", tt.args)
+			}
+		})
+	}
+}
+
+func TestFileEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{"file run", func() error { _, err := File("").Run(); return err }},
+		{"file output", func() error { _, err := File("").Output(); return err }},
+		{"file with args", func() error { _, err := NewOptions().FileWithArgs("", "a").Output(); return err }},
+		{"cmd err", func() error { return File("").Err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, ErrEmptyFile) {
+				t.Errorf("err = %v, want ErrEmptyFile", err)
+			}
+		})
+	}
+}
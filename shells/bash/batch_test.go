@@ -0,0 +1,78 @@
+package bash
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	outs, err := Batch([]string{"echo a", "x=1; exit 3", `echo "${x:-unset}"`, "cd /; pwd", "false | true", "echo b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		code   int
+		stdout string
+	}{
+		{0, "a\n"},
+		{3, ""},
+		{0, "unset\n"},
+		{0, "/\n"},
+		{1, ""},
+		{0, "b\n"},
+	}
+
+	if len(outs) != len(want) {
+		t.Fatalf("got %d outputs, want %d", len(outs), len(want))
+	}
+
+	for i, w := range want {
+		if outs[i].Code != w.code || string(outs[i].Stdout) != w.stdout {
+			t.Errorf("script %d: code %d stdout %q, want %d %q", i, outs[i].Code, outs[i].Stdout, w.code, w.stdout)
+		}
+	}
+}
+
+func benchmarkScripts(n int) []string {
+	scripts := make([]string, n)
+	for i := range scripts {
+		scripts[i] = "echo " + strconv.Itoa(i)
+	}
+
+	return scripts
+}
+
+func BenchmarkBatch(b *testing.B) {
+	if Which() == "" {
+		b.Skip("bash not found")
+	}
+
+	scripts := benchmarkScripts(50)
+	b.ResetTimer()
+	for range b.N {
+		if _, err := Batch(scripts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkOutput(b *testing.B) {
+	if Which() == "" {
+		b.Skip("bash not found")
+	}
+
+	scripts := benchmarkScripts(50)
+	b.ResetTimer()
+	for range b.N {
+		for _, script := range scripts {
+			if _, err := Output(script); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
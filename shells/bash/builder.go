@@ -0,0 +1,149 @@
+package bash
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Builder configures a bash command with chained calls and creates
+// it with Build or runs it with Run or Output. Every method returns
+// a modified copy, so a base builder can be shared and specialized
+// without affecting it. The command is the same as the one created
+// by Options for the same settings.
+//
+// Example:
+//
+//	base := bash.NewBuilder().Cwd("/app").Env(map[string]string{"CI": "true"})
+//	base.Script("make build").Run()
+//	base.Script("make test").ErrExit(false).Timeout(10 * time.Minute).Output()
+type Builder struct {
+	options Options
+	script  string
+	args    []string
+}
+
+// Creates a new builder initialized from the package level
+// defaults the same as NewOptions.
+func NewBuilder() Builder {
+	return Builder{options: *NewOptions()}
+}
+
+// Sets the inline script or file to run
+func (b Builder) Script(script string) Builder {
+	b = b.clone()
+	b.script = script
+	return b
+}
+
+// Sets the positional arguments passed to the script
+func (b Builder) Args(args ...string) Builder {
+	b = b.clone()
+	b.args = slices.Clone(args)
+	return b
+}
+
+// Sets the working directory of the command
+func (b Builder) Cwd(dir string) Builder {
+	b = b.clone()
+	b.options.Dir = dir
+	return b
+}
+
+// Sets environment variables that are merged onto the current
+// process environment, or replace it when ClearEnv is set
+func (b Builder) Env(env map[string]string) Builder {
+	b = b.clone()
+	b.options.WithEnv(env)
+	return b
+}
+
+// Sets whether the current process environment is excluded
+// from the command environment
+func (b Builder) ClearEnv(clear bool) Builder {
+	b = b.clone()
+	b.options.ClearEnv = clear
+	return b
+}
+
+// Sets whether bash exits on the first failing command with -e
+func (b Builder) ErrExit(errExit bool) Builder {
+	b = b.clone()
+	b.options.ErrExit = errExit
+	return b
+}
+
+// Sets whether a pipeline fails when any command in it fails
+func (b Builder) PipeFail(pipeFail bool) Builder {
+	b = b.clone()
+	b.options.PipeFail = pipeFail
+	return b
+}
+
+// Sets whether unset variables are an error with -u
+func (b Builder) NoUnset(noUnset bool) Builder {
+	b = b.clone()
+	b.options.NoUnset = noUnset
+	return b
+}
+
+// Sets whether bash runs as a login shell
+func (b Builder) Login(login bool) Builder {
+	b = b.clone()
+	b.options.Login = login
+	return b
+}
+
+// Sets the maximum duration of Run and Output. Commands created
+// with Build are not limited.
+func (b Builder) Timeout(timeout time.Duration) Builder {
+	b = b.clone()
+	b.options.Timeout = timeout
+	return b
+}
+
+// Returns a copy of the options of the builder for the settings
+// that have no builder method
+func (b Builder) Options() *Options {
+	o := b.clone().options
+	return &o
+}
+
+// Creates the bash command for the script and arguments
+func (b Builder) Build() *exec.Cmd {
+	return b.options.ScriptWithArgs(b.script, b.args...)
+}
+
+// Runs the script with stdout and stderr inherited from the
+// current process the same as Options.Run
+func (b Builder) Run() (*exec.PsOutput, error) {
+	o := b.Options()
+	cmd := o.runScript(b.script, b.args...)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := o.run(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
+
+// Runs the script and captures stdout and stderr
+func (b Builder) Output() (*exec.PsOutput, error) {
+	o := b.Options()
+	cmd := o.runScript(b.script, b.args...)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Output(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
+
+// returns a copy that does not share the env, slices or the
+// arguments with the builder
+func (b Builder) clone() Builder {
+	b.options.Env = maps.Clone(b.options.Env)
+	b.options.WslEnv = slices.Clone(b.options.WslEnv)
+	b.args = slices.Clone(b.args)
+	return b
+}
@@ -0,0 +1,18 @@
+package bash
+
+import "github.com/jolt9dev/go-spawn/internal/lookup"
+
+// Returns the locations probed for bash on the current os in
+// order with environment variables such as ${ProgramFiles}
+// expanded, starting with BASH_PATH when set. PATH is searched
+// when none of them exist. These are the locations listed by
+// ErrShellNotFound.
+//
+// Example:
+//
+//	if bash.Which() == "" {
+//		fmt.Println("bash not found, searched:", strings.Join(bash.Candidates(), ", "))
+//	}
+func Candidates() []string {
+	return lookup.Candidates("bash")
+}
@@ -0,0 +1,53 @@
+package bash
+
+import "strings"
+
+// Creates new options from the package level defaults that run the
+// cleanup script when inline scripts exit, see Options.WithCleanup.
+//
+// Example:
+//
+//	bash.WithCleanup(`rm -rf "$WORK"`).Run(`WORK=$(mktemp -d)
+//	tar -xzf release.tgz -C "$WORK"
+//	"$WORK/install.sh"`)
+func WithCleanup(cleanup string) *Options {
+	return NewOptions().WithCleanup(cleanup)
+}
+
+// Adds a cleanup script that runs when inline scripts exit by
+// injecting trap '<cleanup>' EXIT at the top of the script, after
+// the shebang if any. The cleanup runs after the body of the script
+// has finished, also when a command fails with -e or the script
+// calls exit, and the exit code of the body is kept unless the
+// cleanup calls exit itself. A failing command in the cleanup does
+// not stop it. Cleanups added more than once run in the order they
+// were added. The trap is replaced when the script sets its own
+// EXIT trap and it does not run when bash is killed. Script files
+// are not changed.
+func (o *Options) WithCleanup(cleanup string) *Options {
+	if o.Cleanup != "" {
+		cleanup = o.Cleanup + "\n" + cleanup
+	}
+
+	o.Cleanup = cleanup
+	return o
+}
+
+// injects the EXIT trap for the cleanup of the options into the
+// inline script
+func (o *Options) withCleanup(script string) string {
+	if o.Cleanup == "" {
+		return script
+	}
+
+	// the cleanup runs outside of -e so that a failing command does
+	// not replace the exit code of the body, which it still sees as $?
+	cleanup := "__spawn_status=$?\n{ (exit $__spawn_status)\n" + o.Cleanup + "\n} || true\nexit $__spawn_status"
+	trap := "trap " + Quote(cleanup) + " EXIT\n"
+	if strings.HasPrefix(strings.TrimPrefix(script, "\ufeff"), "#!") {
+		shebang, body, _ := strings.Cut(script, "\n")
+		return shebang + "\n" + trap + body
+	}
+
+	return trap + script
+}
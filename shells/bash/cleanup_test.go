@@ -0,0 +1,50 @@
+package bash
+
+import "testing"
+
+func TestWithCleanup(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []struct {
+		name    string
+		cleanup []string
+		script  string
+		stdout  string
+		code    int
+	}{
+		{"success", []string{"echo cleanup"}, "echo body", "body\ncleanup\n", 0},
+		{"exit code kept", []string{"echo cleanup"}, "echo body; exit 3", "body\ncleanup\n", 3},
+		{"failing command with -e", []string{"echo cleanup"}, "echo body; false; echo unreachable", "body\ncleanup\n", 1},
+		{"failing pipeline", []string{"echo cleanup"}, "false | true; echo unreachable", "cleanup\n", 1},
+		{"failing cleanup keeps the code", []string{"false"}, "exit 4", "", 4},
+		{"cleanup exit wins", []string{"exit 5"}, "exit 4", "", 5},
+		{"cleanup sees the code", []string{`echo "code $?"`}, "exit 6", "code 6\n", 6},
+		{"failing cleanup continues", []string{"false", "echo after"}, "true", "after\n", 0},
+		{"order", []string{"echo first", "echo second"}, "true", "first\nsecond\n", 0},
+		{"quotes", []string{`echo "it's $((1 + 1))"`}, "true", "it's 2\n", 0},
+		{"shebang", []string{"echo cleanup"}, "#!/usr/bin/env bash\necho body", "body\ncleanup\n", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions()
+			for _, cleanup := range tt.cleanup {
+				opts.WithCleanup(cleanup)
+			}
+
+			out, _ := opts.Output(tt.script)
+			if string(out.Stdout) != tt.stdout || out.Code != tt.code {
+				t.Errorf("got %q with code %d, want %q with code %d", out.Stdout, out.Code, tt.stdout, tt.code)
+			}
+		})
+	}
+}
+
+func TestWithCleanupLeavesFilesUnchanged(t *testing.T) {
+	args := WithCleanup("echo cleanup").Script("run.sh").Args
+	if args[len(args)-1] != "run.sh" {
+		t.Errorf("args %v do not end with the file", args)
+	}
+}
@@ -0,0 +1,31 @@
+package bash
+
+import (
+	"context"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Outputs a new bash inline script or file with stdout and stderr
+// captured together in order like 2>&1. The combined output is
+// returned in Stdout and Stderr is empty. The interleaving is best
+// effort since programs may buffer stdout and stderr differently.
+//
+// Example:
+//
+//	out, err := bash.OutputCombined("make build")
+//	log.Println(out.Text())
+func OutputCombined(script string) (*exec.PsOutput, error) {
+	return NewOptions().OutputCombined(script)
+}
+
+// Outputs the inline script or file with stdout and stderr
+// captured together in order
+func (o *Options) OutputCombined(script string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Combined(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
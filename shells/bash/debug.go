@@ -0,0 +1,22 @@
+package bash
+
+import "github.com/jolt9dev/go-exec"
+
+// Creates a new bash command with the given inline script or file
+// that traces each command with -x before it runs. bash writes the
+// trace to stderr prefixed with +, so enabling it changes the
+// stderr captured by Output.
+//
+// Example:
+//
+//	out, _ := bash.ScriptDebug("make build").Output()
+//	fmt.Println(string(out.Stderr)) // + make build
+func ScriptDebug(script string) *exec.Cmd {
+	return NewOptions().WithDebug(true).Script(script)
+}
+
+// Sets whether each command is traced to stderr with -x
+func (o *Options) WithDebug(debug bool) *Options {
+	o.Xtrace = debug
+	return o
+}
@@ -0,0 +1,104 @@
+package bash
+
+import (
+	"context"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// The defaults applied to every bash command created by the
+// package. Set the fields once at startup, before commands are
+// created from other goroutines.
+//
+// Example:
+//
+//	bash.Defaults.Cwd = "/app"
+//	bash.Defaults.Env = map[string]string{"CI": "true"}
+//	bash.Defaults.Timeout = 5 * time.Minute
+var Defaults CommandDefaults
+
+// Called with each command right before Run, Output and the other
+// functions of the package that run a command start the process.
+// It is called after every option, such as the flags, environment,
+// working directory and WSL translation, has been applied and
+// after stdin, stdout and stderr have been set, so replacing the
+// stdio of the command breaks the capture of Output. Use it to
+// attach tracing, adjust the environment or set process attributes.
+// It is not called for commands that are run directly with the
+// methods of exec.Cmd, or when a test runner is set. Set it once
+// at startup.
+//
+// Example:
+//
+//	bash.BeforeRun = func(cmd *exec.Cmd) {
+//		log.Println("running", cmd.Args)
+//	}
+var BeforeRun func(cmd *exec.Cmd)
+
+// CommandDefaults holds the values applied to every command. The
+// values are copied into the options returned by NewOptions and
+// into the commands returned by New, so options set per command
+// take precedence and later changes do not affect existing
+// commands.
+type CommandDefaults struct {
+	// The working directory of the command.
+	Cwd string
+
+	// Environment variables merged onto the current process
+	// environment.
+	Env map[string]string
+
+	// The maximum duration of commands run by the package, e.g.
+	// Run and Output. The process tree is killed once exceeded.
+	// Commands returned by New are not limited since they are
+	// run by the caller.
+	Timeout time.Duration
+}
+
+// Sets the maximum duration of the command. Zero or less
+// means no limit.
+func (o *Options) WithTimeout(timeout time.Duration) *Options {
+	o.Timeout = timeout
+	return o
+}
+
+// returns a copy of the default environment variables
+func defaultEnv() map[string]string {
+	if len(Defaults.Env) == 0 {
+		return nil
+	}
+
+	env := make(map[string]string, len(Defaults.Env))
+	for k, v := range Defaults.Env {
+		env[k] = v
+	}
+
+	return env
+}
+
+// applies the default working directory and environment to a
+// command created with New
+func applyDefaults(cmd *exec.Cmd) *exec.Cmd {
+	cmd.Dir = Defaults.Cwd
+	if env := defaultEnv(); env != nil {
+		cmd.Env = proc.MergeEnv(env, false)
+	}
+
+	return cmd
+}
+
+// returns the context used to run the command which is limited
+// by the timeout and output limit of the options, forwards signals
+// when enabled and calls BeforeRun and AuditLog
+func (o *Options) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx = proc.WithBeforeRun(runContext(ctx), beforeRun())
+	ctx = proc.WithOutputLimit(ctx, o.MaxOutputBytes)
+	ctx = proc.WithForwardSignals(ctx, o.ForwardSignals)
+	if o.Timeout > 0 {
+		return context.WithTimeout(ctx, o.Timeout)
+	}
+
+	return ctx, func() {}
+}
@@ -0,0 +1,69 @@
+package bash
+
+import (
+	"fmt"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-fs"
+)
+
+// Creates a new bash command with the given inline script or file
+// that runs in the given working directory. When the directory
+// does not exist or is not a directory, the error is returned when
+// the command is run without spawning the process. When running
+// in a WSL distribution, the directory is translated to its /mnt
+// path.
+//
+// Example:
+//
+//	bash.ScriptIn("/path/to/dir", "make build").Run()
+func ScriptIn(dir, script string) *exec.Cmd {
+	return NewOptions().WithDir(dir).Script(script)
+}
+
+// Runs a new bash inline script or file in the given working
+// directory with stdout and stderr inherited from the current
+// process. See ScriptIn.
+//
+// Example:
+//
+//	bash.RunIn("/path/to/dir", "make build")
+func RunIn(dir, script string) (*exec.PsOutput, error) {
+	return NewOptions().WithDir(dir).Run(script)
+}
+
+// Outputs a new bash inline script or file in the given working
+// directory and captures stdout and stderr. See ScriptIn.
+//
+// Example:
+//
+//	out, err := bash.OutputIn("/path/to/dir", "git status --short")
+func OutputIn(dir, script string) (*exec.PsOutput, error) {
+	return NewOptions().WithDir(dir).Output(script)
+}
+
+// Sets the working directory of the command
+func (o *Options) WithDir(dir string) *Options {
+	o.Dir = dir
+	return o
+}
+
+// sets the working directory of the command and records an error
+// on the command when the directory is invalid
+func (o *Options) applyDir(cmd *exec.Cmd) {
+	if o.Dir == "" {
+		return
+	}
+
+	cmd.Dir = o.Dir
+	if cmd.Err != nil {
+		return
+	}
+
+	fi, err := fs.Stat(o.Dir)
+	if err != nil {
+		cmd.Err = fmt.Errorf("bash: working directory %s does not exist: %w", o.Dir, err)
+	} else if !fi.IsDir() {
+		cmd.Err = fmt.Errorf("bash: working directory %s is not a directory", o.Dir)
+	}
+}
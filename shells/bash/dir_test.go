@@ -0,0 +1,81 @@
+package bash
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOutputIn(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		dir      string
+		want     string
+		err      string
+		notExist bool
+	}{
+		{"dir", dir, real, "", false},
+		{"missing", filepath.Join(dir, "missing"), "", "does not exist", true},
+		{"file", file, "", "is not a directory", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := OutputIn(tt.dir, "pwd -P")
+			if tt.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got := strings.TrimSpace(string(out.Stdout)); got != tt.want {
+					t.Errorf("pwd = %q, want %q", got, tt.want)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("err = %v, want %q", err, tt.err)
+			}
+
+			if tt.notExist && !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("err = %v, want it to wrap fs.ErrNotExist", err)
+			}
+		})
+	}
+}
+
+func TestScriptInDoesNotStartInvalidDir(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "started")
+	_, err := RunIn(filepath.Join(dir, "missing"), "touch "+Quote(marker))
+	if err == nil {
+		t.Fatal("want an error for a missing directory")
+	}
+
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("the script ran although the directory is missing")
+	}
+}
@@ -0,0 +1,167 @@
+package bash
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Creates a new bash command with the given inline script or file
+// and environment variables merged onto the current process
+// environment.
+//
+// Example:
+//
+//	bash.ScriptWithEnv("echo $NAME", map[string]string{"NAME": "world"}).Output()
+func ScriptWithEnv(script string, env map[string]string) *exec.Cmd {
+	return NewOptions().WithEnv(env).Script(script)
+}
+
+// Creates new options from the package level defaults with
+// the given environment variables.
+//
+// Example:
+//
+//	bash.WithEnv(map[string]string{"NAME": "world"}).Run("echo $NAME")
+func WithEnv(env map[string]string) *Options {
+	return NewOptions().WithEnv(env)
+}
+
+// Sets environment variables that are merged onto the current
+// process environment, or replace it when ClearEnv is set
+func (o *Options) WithEnv(env map[string]string) *Options {
+	if o.Env == nil {
+		o.Env = map[string]string{}
+	}
+
+	for k, v := range env {
+		o.Env[k] = v
+	}
+
+	return o
+}
+
+// Sets whether the current process environment is excluded
+// from the command environment
+func (o *Options) WithClearEnv(clear bool) *Options {
+	o.ClearEnv = clear
+	return o
+}
+
+// Creates new options from the package level defaults that run
+// bash with a minimal environment made of the given variables of
+// the current process and PATH, which keeps host variables such as
+// tokens from leaking into the script and makes runs reproducible.
+// On Windows, SystemRoot is kept as well since processes fail to
+// load system libraries without it. When PATH is not set, a default
+// search path is used. Variables set with WithEnv are still added.
+//
+// Example:
+//
+//	bash.WithCleanEnv("HOME", "GOPATH").Run("go build ./...")
+func WithCleanEnv(keep ...string) *Options {
+	return NewOptions().WithCleanEnv(keep...)
+}
+
+// Sets the command environment to the given variables of the
+// current process and PATH, excluding everything else
+func (o *Options) WithCleanEnv(keep ...string) *Options {
+	o.ClearEnv = true
+	return o.WithEnv(proc.CleanEnv(keep))
+}
+
+// Creates new options from the package level defaults that
+// forward the given windows environment variables into WSL by
+// adding them to WSLENV. See Options.WithWslEnv.
+//
+// Example:
+//
+//	bash.WithWslEnv("GOPATH/p", "GITHUB_TOKEN").Run("echo $GOPATH")
+func WithWslEnv(names ...string) *Options {
+	return NewOptions().WithWslEnv(names...)
+}
+
+// Adds windows environment variables that are forwarded into WSL
+// with WSLENV. A name may end with the WSLENV flags such as /p to
+// translate a path, /l to translate a list of paths or /u to only
+// forward the variable from windows to WSL. The names are appended
+// to the existing WSLENV, replacing entries with the same name.
+// Has no effect when bash does not run inside WSL.
+func (o *Options) WithWslEnv(names ...string) *Options {
+	o.WslEnv = append(o.WslEnv, names...)
+	return o
+}
+
+// sets the command environment from the options. When running
+// in WSL, the variables are added to WSLENV so that they are
+// forwarded into the distribution.
+func (o *Options) applyEnv(cmd *exec.Cmd) {
+	wsl := o.useWsl() && (len(o.Env) > 0 || len(o.WslEnv) > 0)
+	if len(o.Env) == 0 && !o.ClearEnv && !wsl {
+		return
+	}
+
+	env := map[string]string{}
+	for k, v := range o.Env {
+		env[k] = v
+	}
+
+	if wsl {
+		wslenv, _ := proc.LookupEnv(proc.MergeEnv(env, o.ClearEnv), "WSLENV")
+		env["WSLENV"] = appendWslEnv(wslenv, o.Env, o.WslEnv)
+	}
+
+	cmd.Env = proc.MergeEnv(env, o.ClearEnv)
+}
+
+// appends the names of env that are not already listed to wslenv
+// followed by the entries of forward, which replace any existing
+// entry of the same name
+func appendWslEnv(wslenv string, env map[string]string, forward []string) string {
+	names := []string{}
+	for k := range env {
+		if k != "WSLENV" {
+			names = append(names, k)
+		}
+	}
+
+	sort.Strings(names)
+	parts := []string{}
+	if wslenv != "" {
+		parts = strings.Split(wslenv, ":")
+	}
+
+	index := func(name string) int {
+		for i, p := range parts {
+			n, _, _ := strings.Cut(p, "/")
+			if n == name {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	for _, name := range names {
+		if index(name) < 0 {
+			parts = append(parts, name)
+		}
+	}
+
+	for _, entry := range forward {
+		name, _, _ := strings.Cut(entry, "/")
+		if name == "" {
+			continue
+		}
+
+		if i := index(name); i >= 0 {
+			parts[i] = entry
+		} else {
+			parts = append(parts, entry)
+		}
+	}
+
+	return strings.Join(parts, ":")
+}
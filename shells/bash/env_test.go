@@ -0,0 +1,67 @@
+package bash
+
+import "testing"
+
+func TestEnv(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	t.Setenv("SPAWN_TEST_PARENT", "parent")
+
+	tests := []struct {
+		name string
+		opts *Options
+		want string
+	}{
+		{"inherit", NewOptions(), "parent|"},
+		{"add", WithEnv(map[string]string{"SPAWN_TEST_CHILD": "child"}), "parent|child"},
+		{"override", WithEnv(map[string]string{"SPAWN_TEST_PARENT": "child"}), "child|"},
+		{"unset", NewOptions().WithClearEnv(true).WithEnv(map[string]string{"PATH": "/usr/bin:/bin"}), "|"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.opts.Output(`printf '%s|%s' "${SPAWN_TEST_PARENT:-}" "${SPAWN_TEST_CHILD:-}"`)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := string(out.Stdout); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanEnv(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	t.Setenv("SPAWN_TEST_SECRET", "secret")
+	t.Setenv("SPAWN_TEST_KEEP", "keep")
+
+	tests := []struct {
+		name string
+		opts *Options
+		want string
+	}{
+		{"hides variables", WithCleanEnv(), "||"},
+		{"keeps listed variables", WithCleanEnv("SPAWN_TEST_KEEP"), "|keep|"},
+		{"adds env", WithCleanEnv().WithEnv(map[string]string{"SPAWN_TEST_ADDED": "added"}), "||added"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.opts.Output(`printf '%s|%s|%s' "${SPAWN_TEST_SECRET:-}" "${SPAWN_TEST_KEEP:-}" "${SPAWN_TEST_ADDED:-}"; command -v env >/dev/null`)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := string(out.Stdout); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package bash
+
+import (
+	"errors"
+	"fmt"
+	osexec "os/exec"
+	"strings"
+
+	"github.com/jolt9dev/go-spawn/internal/envdump"
+)
+
+// Outputs a new bash inline script or file and returns the
+// environment variables the script added or changed, which brings
+// the result of sourcing a script such as an activate or setup
+// script into the Go process. The environment is dumped with env -0
+// before and after the script, so values that span lines are
+// returned intact, and variables the script removed with unset are
+// not reported. Files are sourced with the . builtin so that their
+// exports are visible. The stdout of the script is discarded. An
+// error is returned when the script exits with a non-zero code or
+// calls exit before the environment is dumped.
+//
+// Example:
+//
+//	env, err := bash.OutputEnv(". ./venv/bin/activate")
+//	if err != nil {
+//		return err
+//	}
+//
+//	for k, v := range env {
+//		os.Setenv(k, v)
+//	}
+func OutputEnv(script string) (map[string]string, error) {
+	return NewOptions().OutputEnv(script)
+}
+
+// Outputs the inline script or file and returns the environment
+// variables the script added or changed
+func (o *Options) OutputEnv(script string) (map[string]string, error) {
+	marker, err := envdump.Marker()
+	if err != nil {
+		return nil, err
+	}
+
+	if trimmed := strings.TrimSpace(script); !strings.Contains(script, "\n") && strings.HasSuffix(trimmed, ".sh") {
+		script = ". " + Quote(trimmed)
+	}
+
+	// the || keeps bash from exec'ing the last env, which changes
+	// SHLVL, and stops early when env does not support -0
+	dump := "printf '%s\\0' '" + marker + "'; env -0 || exit"
+	out, err := o.Output(dump + "; printf '%s\\0' '" + marker + "'\n" + script + "\n" + dump)
+	if out == nil {
+		return nil, err
+	}
+
+	var exitErr *osexec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, err
+	}
+
+	if out.Code != 0 {
+		stderr := strings.TrimSpace(string(out.Stderr))
+		if stderr != "" {
+			stderr = ": " + stderr
+		}
+
+		return nil, fmt.Errorf("bash: script exited with code %d%s", out.Code, stderr)
+	}
+
+	env, err := envdump.Diff(out.Stdout, marker)
+	if err != nil {
+		return nil, fmt.Errorf("bash: %w", err)
+	}
+
+	return env, nil
+}
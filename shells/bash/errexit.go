@@ -0,0 +1,34 @@
+package bash
+
+import "github.com/jolt9dev/go-exec"
+
+// Creates a new bash command with the given inline script or file
+// without -e, so the script keeps running after a command fails and
+// can check $? itself. pipefail and the isolation from profiles
+// are kept.
+//
+// Example:
+//
+//	bash.ScriptNoErrExit(`grep -q foo config || echo "foo is missing"`).Run()
+func ScriptNoErrExit(script string) *exec.Cmd {
+	return NewOptions().WithErrExit(false).Script(script)
+}
+
+// Runs a new bash inline script or file without -e with stdout and
+// stderr inherited from the current process. See ScriptNoErrExit.
+//
+// Example:
+//
+//	bash.RunNoErrExit(`make test
+//	status=$?
+//	make clean
+//	exit $status`)
+func RunNoErrExit(script string) (*exec.PsOutput, error) {
+	return NewOptions().WithErrExit(false).Run(script)
+}
+
+// Sets whether bash exits on the first failing command with -e
+func (o *Options) WithErrExit(errExit bool) *Options {
+	o.ErrExit = errExit
+	return o
+}
@@ -0,0 +1,63 @@
+package bash
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+func TestScriptNoErrExitFlags(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		errExit bool
+	}{
+		{"script", Script("echo a").Args, true},
+		{"no errexit", ScriptNoErrExit("echo a").Args, false},
+		{"options", NewOptions().WithErrExit(false).Script("echo a").Args, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := tt.args[1:slices.Index(tt.args, "-c")]
+			if got := slices.Contains(flags, "-e"); got != tt.errExit {
+				t.Errorf("-e in %v = %v, want %v", flags, got, tt.errExit)
+			}
+
+			if Features().PipeFail && !slices.Contains(flags, "pipefail") {
+				t.Errorf("flags %v do not contain pipefail", flags)
+			}
+		})
+	}
+}
+
+func TestScriptNoErrExit(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	script := `false; echo "status $?"`
+	tests := []struct {
+		name   string
+		cmd    *exec.Cmd
+		stdout string
+		code   int
+	}{
+		{"errexit", Script(script), "", 1},
+		{"no errexit", ScriptNoErrExit(script), "status 1\n", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, _ := tt.cmd.Output()
+			if string(out.Stdout) != tt.stdout || out.Code != tt.code {
+				t.Errorf("got %q with code %d, want %q with code %d", out.Stdout, out.Code, tt.stdout, tt.code)
+			}
+		})
+	}
+}
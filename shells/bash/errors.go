@@ -0,0 +1,50 @@
+package bash
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrEmptyFile is returned when running a command created by
+// File with an empty file path.
+var ErrEmptyFile = errors.New("bash: script file path is empty")
+
+// ErrUNCPath is returned when running a command created by File
+// with a UNC path such as \\server\share\script.sh in WSL, which
+// cannot access network shares unless they are mapped to a drive
+// letter or mounted in the distribution.
+var ErrUNCPath = errors.New("bash: UNC paths are not accessible in WSL, map the share to a drive letter or mount it in the distribution")
+
+// ErrNoWslDistro is returned when running a command with the
+// System32 bash.exe of WSL while no distribution is installed.
+var ErrNoWslDistro = errors.New("bash: WSL has no distribution installed, install one with wsl --install or use Git-Bash")
+
+// ErrShellNotFound is returned when the bash executable cannot be
+// found and lists the locations that were probed.
+type ErrShellNotFound struct {
+	Name       string
+	Candidates []string
+}
+
+func (e *ErrShellNotFound) Error() string {
+	return fmt.Sprintf("%s not found, searched %s and PATH", e.Name, strings.Join(e.Candidates, ", "))
+}
+
+// Returns the path to the bash executable or an *ErrShellNotFound
+// error that lists the locations that were probed.
+//
+// Example:
+//
+//	exe, err := bash.WhichE()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func WhichE() (string, error) {
+	exe := Which()
+	if exe == "" {
+		return "", &ErrShellNotFound{Name: "bash", Candidates: Candidates()}
+	}
+
+	return exe, nil
+}
@@ -0,0 +1,57 @@
+package bash
+
+import (
+	"errors"
+	"fmt"
+	osexec "os/exec"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// ErrUnexpectedCode is wrapped by the error RunExpect returns when
+// the script exits with a code other than the expected one.
+var ErrUnexpectedCode = errors.New("bash: unexpected exit code")
+
+// Runs a new bash inline script or file, captures stdout and stderr
+// and returns an error when the exit code differs from the expected
+// one, including a non-zero expected code, e.g. grep exits with 1
+// when nothing matches. The error wraps ErrUnexpectedCode and holds
+// both codes and the captured stderr. Errors that prevent the script
+// from running are returned as is.
+//
+// Example:
+//
+//	_, err := bash.RunExpect("grep -q TODO main.go", 1)
+//	if err != nil {
+//		log.Fatal(err) // bash: unexpected exit code: expected 1, got 0
+//	}
+func RunExpect(script string, expected int) (*exec.PsOutput, error) {
+	return NewOptions().RunExpect(script, expected)
+}
+
+// Runs the inline script or file, captures stdout and stderr and
+// returns an error when the exit code differs from the expected one
+func (o *Options) RunExpect(script string, expected int) (*exec.PsOutput, error) {
+	out, err := o.Output(script)
+	if out == nil {
+		return out, err
+	}
+
+	// a non-zero exit code is reported by the comparison below
+	var exitErr *osexec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return out, err
+	}
+
+	if out.Code == expected {
+		return out, nil
+	}
+
+	stderr := strings.TrimSpace(string(out.Stderr))
+	if stderr != "" {
+		stderr = ": " + stderr
+	}
+
+	return out, fmt.Errorf("%w: expected %d, got %d%s", ErrUnexpectedCode, expected, out.Code, stderr)
+}
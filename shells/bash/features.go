@@ -0,0 +1,51 @@
+package bash
+
+import (
+	"github.com/jolt9dev/go-spawn/internal/busybox"
+	"github.com/jolt9dev/go-spawn/internal/probe"
+	"github.com/jolt9dev/go-spawn/shells"
+)
+
+// Returns the features of the resolved bash. busybox ash, which
+// is bash on some minimal container images, supports -e and -u but
+// not process substitution or arrays, while pipefail is only
+// supported by newer versions and is probed once per executable
+// the same as sh.Features. The zero value is returned when bash is
+// not installed.
+//
+// Example:
+//
+//	if !bash.Features().ProcessSubstitution {
+//		script = "sort a > a.sorted; sort b > b.sorted; diff a.sorted b.sorted"
+//	}
+func Features() shells.ShellFeatures {
+	exe := Which()
+	if exe == "" {
+		return shells.ShellFeatures{}
+	}
+
+	return features(exe, IsBusybox())
+}
+
+// returns the features of bash or of busybox ash, which the flags
+// of the options are also derived from
+func features(exe string, bb bool) shells.ShellFeatures {
+	return shells.ShellFeatures{
+		ErrExit:             true,
+		PipeFail:            !bb || busyboxPipeFail(exe),
+		NoUnset:             true,
+		ProcessSubstitution: !bb,
+		Arrays:              !bb,
+	}
+}
+
+// reports whether busybox ash accepts set -o pipefail, running the
+// applet through busybox itself when the executable is busybox
+func busyboxPipeFail(exe string) bool {
+	args := []string{"-c", "set -o pipefail"}
+	if busybox.IsName(exe) {
+		args = append([]string{"sh"}, args...)
+	}
+
+	return probe.Succeeds(exe, args...)
+}
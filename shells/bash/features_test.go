@@ -0,0 +1,68 @@
+package bash
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestFlagsFollowFeatures(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	f := Features()
+	flags := NewOptions().flags()
+	if got := slices.Contains(flags, "pipefail"); got != f.PipeFail {
+		t.Errorf("pipefail in %v = %v, want %v", flags, got, f.PipeFail)
+	}
+
+	if got := slices.Contains(flags, "--norc"); got == IsBusybox() {
+		t.Errorf("--norc in %v = %v with busybox %v", flags, got, IsBusybox())
+	}
+}
+
+func TestFeaturesBusybox(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake busybox executables are shell scripts")
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		script   string
+		pipeFail bool
+	}{
+		{"pipefail", "ash", "exit 0", true},
+		{"old version", "ash", "echo 'sh: set: illegal option -o pipefail' >&2; exit 2", false},
+		{"busybox applet", "busybox", `[ "$1" = sh ] && [ "$3" = "set -o pipefail" ]`, true},
+		{"missing", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exe := ""
+			if tt.file != "" {
+				exe = filepath.Join(t.TempDir(), tt.file)
+				if err := os.WriteFile(exe, []byte("#!/bin/sh\n"+tt.script+"\n"), 0700); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			f := features(exe, true)
+			if !f.ErrExit || !f.NoUnset {
+				t.Errorf("busybox ash supports -e and -u, got %+v", f)
+			}
+
+			if f.ProcessSubstitution || f.Arrays {
+				t.Errorf("busybox ash supports neither process substitution nor arrays, got %+v", f)
+			}
+
+			if f.PipeFail != tt.pipeFail {
+				t.Errorf("PipeFail = %v, want %v", f.PipeFail, tt.pipeFail)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package bash
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Runs a new bash inline script or file with the input written
+// to stdin. Stdout and stderr are inherited from the current
+// process.
+//
+// Example:
+//
+//	bash.RunWithInput("cat", "hello")
+func RunWithInput(script, input string) (*exec.PsOutput, error) {
+	return NewOptions().RunWithInput(script, input)
+}
+
+// Outputs a new bash inline script or file with the input written
+// to stdin and captures stdout and stderr.
+//
+// Example:
+//
+//	out, err := bash.OutputWithInput("tr a-z A-Z", "hello")
+func OutputWithInput(script, input string) (*exec.PsOutput, error) {
+	return NewOptions().OutputWithInput(script, input)
+}
+
+// Runs the inline script or file with the input written to stdin
+func (o *Options) RunWithInput(script, input string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Wait(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
+
+// Runs the inline script or file with the input written to stdin
+// and captures stdout and stderr
+func (o *Options) OutputWithInput(script, input string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	cmd.Stdin = strings.NewReader(input)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Output(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
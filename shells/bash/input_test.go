@@ -0,0 +1,67 @@
+package bash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOutputWithInput(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	large := strings.Repeat("line\n", 200000)
+	tests := []struct {
+		name   string
+		script string
+		input  string
+		want   string
+	}{
+		{"cat", "cat", "hello\nworld\n", "hello\nworld\n"},
+		{"read", `read -r line; echo "got $line"`, "a b\nc\n", "got a b\n"},
+		{"no trailing newline", "cat", "hello", "hello"},
+		{"empty", "cat; echo done", "", "done\n"},
+		{"backslashes and quotes", "cat", `a\b 'c' "d" $e`, `a\b 'c' "d" $e`},
+		{"large", "wc -l", large, "200000\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := OutputWithInput(tt.script, tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.TrimLeft(string(out.Stdout), " "); got != tt.want {
+				t.Errorf("stdout = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunWithInput(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []struct {
+		input string
+		code  int
+	}{
+		{"yes\n", 0},
+		{"no\n", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			out, err := RunWithInput(`read -r x; [ "$x" = yes ]`, tt.input)
+			if tt.code == 0 && err != nil {
+				t.Fatal(err)
+			}
+
+			if out == nil || out.Code != tt.code {
+				t.Errorf("out = %+v, err = %v, want code %d", out, err, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package bash
+
+import (
+	"strings"
+
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Outputs a new bash inline script or file and returns stdout
+// split into trimmed lines without trailing empty lines. The
+// lines captured before a failure are returned with the error.
+//
+// Example:
+//
+//	files, err := bash.OutputLines("git ls-files")
+func OutputLines(script string) ([]string, error) {
+	out, err := Output(script)
+	return proc.Lines(out.Stdout), err
+}
+
+// Outputs a new bash inline script or file and returns stdout
+// with surrounding whitespace trimmed.
+//
+// Example:
+//
+//	branch, err := bash.OutputText("git branch --show-current")
+func OutputText(script string) (string, error) {
+	out, err := Output(script)
+	return strings.TrimSpace(string(out.Stdout)), err
+}
+
+// Outputs a new bash inline script or file and returns stdout
+// with surrounding whitespace trimmed. When the script fails or
+// exits with a non-zero code, an error is returned that includes
+// the exit code and stderr.
+//
+// Example:
+//
+//	version, err := bash.MustOutput("git describe --tags")
+func MustOutput(script string) (string, error) {
+	out, err := Output(script)
+	return proc.Text("bash", out, err)
+}
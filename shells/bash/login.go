@@ -0,0 +1,35 @@
+package bash
+
+import (
+	"github.com/jolt9dev/go-exec"
+)
+
+// Creates a new bash login shell command with the given inline
+// script or file. Login shells source /etc/profile and
+// ~/.bash_profile which picks up tools such as nvm or rbenv, but
+// they are slower to start and the profile scripts can have side
+// effects such as printing output or changing directories. Use
+// Script for the default isolated behavior.
+//
+// Example:
+//
+//	bash.LoginScript("nvm use 20 && npm ci").Run()
+func LoginScript(script string) *exec.Cmd {
+	return NewOptions().WithLogin(true).Script(script)
+}
+
+// Creates a new bash login shell command with the given script
+// file. See LoginScript for the tradeoffs of login shells.
+//
+// Example:
+//
+//	bash.LoginFile("script.sh").Run()
+func LoginFile(file string) *exec.Cmd {
+	return NewOptions().WithLogin(true).File(file)
+}
+
+// Sets whether bash runs as a login shell
+func (o *Options) WithLogin(login bool) *Options {
+	o.Login = login
+	return o
+}
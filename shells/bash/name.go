@@ -0,0 +1,20 @@
+package bash
+
+// Creates new options from the package level defaults that run
+// inline scripts with $0 set to the given name, so that error
+// messages such as "deploy: line 3: foo: command not found" point
+// at the script. Script files and inline scripts written to a
+// temp file keep the path of the file as $0.
+//
+// Example:
+//
+//	bash.WithName("deploy").Run(`echo "running $0"`)
+func WithName(name string) *Options {
+	return NewOptions().WithName(name)
+}
+
+// Sets the name inline scripts see as $0
+func (o *Options) WithName(name string) *Options {
+	o.Name = name
+	return o
+}
@@ -0,0 +1,71 @@
+package bash
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Outputs a new bash inline script or file that writes one JSON
+// value per line to stdout, such as NDJSON or JSON lines, and
+// calls onObject with each value as it is written. Stdout is not
+// buffered, so the output can be larger than memory. Blank lines
+// are skipped and a trailing line without a newline is still
+// parsed. A line that is not valid JSON fails with an error that
+// includes the line number. When onObject returns an error, the
+// process is killed and the error is returned as is. Otherwise a
+// non-zero exit code fails with the captured stderr.
+//
+// Example:
+//
+//	err := bash.OutputNDJSON("docker events --format json --until 0s", func(raw json.RawMessage) error {
+//		var ev struct{ Type, Action string }
+//		if err := json.Unmarshal(raw, &ev); err != nil {
+//			return err
+//		}
+//
+//		log.Println(ev.Type, ev.Action)
+//		return nil
+//	})
+func OutputNDJSON(script string, onObject func(json.RawMessage) error) error {
+	return NewOptions().OutputNDJSON(script, onObject)
+}
+
+// Outputs the inline script or file and calls onObject with each
+// JSON value written as a line to stdout
+func (o *Options) OutputNDJSON(script string, onObject func(json.RawMessage) error) error {
+	cmd := o.runScript(script)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	n := 0
+	var stopped error
+	out, err := proc.ScanLines(ctx, cmd, func(line []byte) error {
+		n++
+		line = bytes.TrimSpace(bytes.TrimPrefix(line, []byte("\ufeff")))
+		if len(line) == 0 {
+			return nil
+		}
+
+		if !json.Valid(line) {
+			stopped = fmt.Errorf("bash: line %d is not valid JSON: %.80q", n, line)
+		} else {
+			stopped = onObject(append(json.RawMessage{}, line...))
+		}
+
+		return stopped
+	})
+	err = o.finish(cmd, err)
+	if stopped != nil {
+		return stopped
+	}
+
+	if out == nil {
+		return err
+	}
+
+	_, err = proc.Text("bash", out, err)
+	return err
+}
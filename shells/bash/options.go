@@ -0,0 +1,314 @@
+package bash
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Options controls how bash inline scripts and files are
+// invoked. Use NewOptions to create options initialized from
+// the package level defaults.
+type Options struct {
+	// Exits on the first failing command with -e.
+	ErrExit bool
+
+	// Fails a pipeline when any command in it fails
+	// with -o pipefail.
+	PipeFail bool
+
+	// Treats unset variables as an error with -u.
+	NoUnset bool
+
+	// Prints each command to stderr before it runs with -x.
+	// See WithDebug.
+	Xtrace bool
+
+	// The WSL distribution used to run bash on Windows. When
+	// empty, DefaultWslDistro is used.
+	WslDistro string
+
+	// The windows environment variables forwarded into WSL with
+	// WSLENV, optionally followed by flags such as /p or /u.
+	WslEnv []string
+
+	// Environment variables merged onto the current process
+	// environment.
+	Env map[string]string
+
+	// When true, the current process environment is not
+	// inherited and only Env is passed to the command.
+	ClearEnv bool
+
+	// The working directory of the command which must exist
+	// before the command is started.
+	Dir string
+
+	// Keeps the temp file written for an inline script on
+	// disk when the command fails.
+	KeepTempOnError bool
+
+	// The directory temp scripts are written to. When empty, the
+	// default temp directory of the os is used.
+	TempDir string
+
+	// Inline scripts larger than this number of bytes are written
+	// to a temp file by Run, Output and the other functions that
+	// run a script. Zero or less always uses -c. Defaults to
+	// ScriptFileThreshold.
+	ScriptFileThreshold int
+
+	// The interpreter line written at the top of temp scripts.
+	// When empty, the resolved bash path is used.
+	Shebang string
+
+	// When true, bash runs as a login shell with -l so that
+	// /etc/profile and ~/.bash_profile are sourced instead of
+	// running isolated with --noprofile and --norc.
+	Login bool
+
+	// The name inline scripts run with -c see as $0, which bash
+	// uses in its error messages. When empty, $0 is bash.
+	Name string
+
+	// The user bash runs as with sudo -u, or wsl.exe -u in a WSL
+	// distribution. When empty, bash runs as the current user.
+	User string
+
+	// The script inline scripts run when they exit with a trap on
+	// EXIT, see WithCleanup.
+	Cleanup string
+
+	// The maximum duration of the command. The process tree is
+	// killed once exceeded. Zero or less means no limit.
+	Timeout time.Duration
+
+	// The time RunTimeout waits for the process to exit after
+	// asking it to terminate before the process tree is killed.
+	GracePeriod time.Duration
+
+	// The maximum number of bytes captured per stream by Output and
+	// the other functions that capture output. The process tree is
+	// killed once exceeded and the error wraps ErrOutputTruncated.
+	// Zero or less means no limit.
+	MaxOutputBytes int
+
+	// When true, the interrupt and terminate signals received by
+	// the current process are forwarded to the process group of
+	// the command while it runs instead of terminating the current
+	// process, see WithForwardSignals.
+	ForwardSignals bool
+
+	// What Run and RunContext do with stdout and stderr, see
+	// RunMode. Defaults to DefaultRunMode.
+	RunMode RunMode
+}
+
+// Creates new options initialized from the package
+// level defaults and Defaults
+func NewOptions() *Options {
+	return &Options{
+		ErrExit:             true,
+		PipeFail:            true,
+		KeepTempOnError:     KeepTempOnError,
+		TempDir:             TempDir,
+		ScriptFileThreshold: ScriptFileThreshold,
+		Dir:                 Defaults.Cwd,
+		Env:                 defaultEnv(),
+		Timeout:             Defaults.Timeout,
+		GracePeriod:         GracePeriod,
+		MaxOutputBytes:      MaxOutputBytes,
+		ForwardSignals:      ForwardSignals,
+		RunMode:             DefaultRunMode,
+	}
+}
+
+// Creates a new bash command with the given inline script or file
+// using the given options.
+//
+// Example:
+//
+//	bash.ScriptWith("grep foo missing.txt || echo none", bash.Options{PipeFail: true}).Run()
+func ScriptWith(script string, options Options) *exec.Cmd {
+	return options.Script(script)
+}
+
+// Creates a new bash command with the given script file using
+// the given options.
+//
+// Example:
+//
+//	bash.FileWith("script.sh", bash.Options{ErrExit: true, NoUnset: true}).Run()
+func FileWith(file string, options Options) *exec.Cmd {
+	return options.File(file)
+}
+
+// Creates a new bash command with the given script file. When
+// bash runs in WSL, the file is translated with TranslatePath
+// and UNC paths outside of the WSL file system fail with
+// ErrUNCPath.
+func (o *Options) File(file string) *exec.Cmd {
+	return o.FileWithArgs(file)
+}
+
+// Creates a new bash command with the given script file followed
+// by the positional arguments passed to the script
+func (o *Options) FileWithArgs(file string, args ...string) *exec.Cmd {
+	if file == "" {
+		cmd := o.command()
+		cmd.Err = ErrEmptyFile
+		return cmd
+	}
+
+	if o.useWsl() {
+		file = TranslatePath(file)
+		if isUNC(file) {
+			cmd := o.command(file)
+			cmd.Err = fmt.Errorf("%w: %s", ErrUNCPath, file)
+			return cmd
+		}
+	}
+
+	return o.command(append([]string{file}, o.scriptArgs(args)...)...)
+}
+
+// Creates a new bash command with the given inline script
+// or file. However, the file must have a .sh extension
+// and be on a single line.
+func (o *Options) Script(script string) *exec.Cmd {
+	return o.ScriptWithArgs(script)
+}
+
+// Creates a new bash command with the given inline script or
+// file and the positional arguments passed to the script as $1,
+// $2 and so on. Inline scripts run with bash -c script -- args.
+func (o *Options) ScriptWithArgs(script string, args ...string) *exec.Cmd {
+	return o.scriptWithArgs(script, args, false)
+}
+
+// creates the command for the functions that run it and remove its
+// temp file once it completes, which are the only ones that write
+// scripts larger than the threshold of the options to a temp file
+func (o *Options) runScript(script string, args ...string) *exec.Cmd {
+	return o.scriptWithArgs(script, args, true)
+}
+
+func (o *Options) scriptWithArgs(script string, args []string, temp bool) *exec.Cmd {
+	if !strings.ContainsAny(script, "\n") {
+		script = strings.TrimSpace(script)
+
+		if strings.HasSuffix(script, ".sh") {
+			return o.FileWithArgs(script, args...)
+		}
+	}
+
+	script = o.withCleanup(script)
+	if temp && o.ScriptFileThreshold > 0 && len(script) > o.ScriptFileThreshold {
+		return o.scriptFile(script, args)
+	}
+
+	if o.Name != "" {
+		return o.command(append([]string{"-c", script, o.Name}, o.scriptArgs(args)...)...)
+	}
+
+	if len(args) == 0 {
+		return o.command("-c", script)
+	}
+
+	return o.command(append([]string{"-c", script, "--"}, o.scriptArgs(args)...)...)
+}
+
+// Runs the inline script or file with stdout and stderr
+// inherited from the current process unless the run mode
+// of the options is set otherwise
+func (o *Options) Run(script string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := o.run(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
+
+// Runs the inline script or file and captures stdout
+// and stderr
+func (o *Options) Output(script string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Output(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
+
+// creates the command with the flags derived from the options
+// followed by the given arguments
+func (o *Options) command(args ...string) *exec.Cmd {
+	var cmd *exec.Cmd
+	args = append(o.flags(), args...)
+	installed, wslExe := detectWsl()
+	if distro := o.wslDistro(); distro != "" && installed {
+		args = append([]string{"--exec", "bash"}, args...)
+		if o.Dir != "" {
+			args = append([]string{"--cd", TranslatePath(o.Dir)}, args...)
+		}
+
+		if o.User != "" {
+			args = append([]string{"-u", o.User}, args...)
+		}
+
+		args = append([]string{"-d", distro}, args...)
+		cmd = exec.New(wslExe, args...)
+	} else {
+		exe, err := WhichE()
+		if err != nil {
+			cmd = exec.New("bash", args...)
+			cmd.Err = err
+		} else if isWslBash(exe) && !HasWslDistro() {
+			cmd = exec.New(exe, args...)
+			cmd.Err = ErrNoWslDistro
+		} else if o.User != "" {
+			cmd = o.sudo(exe, args)
+		} else {
+			cmd = exec.New(exe, args...)
+		}
+	}
+
+	o.applyEnv(cmd)
+	o.applyDir(cmd)
+	return cmd
+}
+
+// returns the flags that precede the script file or -c
+func (o *Options) flags() []string {
+	// busybox ash, e.g. bash linked to busybox on Alpine, fails on
+	// the long options and, in older versions, on pipefail
+	bb := o.wslDistro() == "" && IsBusybox()
+	f := features(Which(), bb)
+	flags := []string{"--noprofile", "--norc"}
+	if o.Login {
+		flags = []string{"-l"}
+	} else if bb {
+		flags = []string{}
+	}
+
+	if o.ErrExit && f.ErrExit {
+		flags = append(flags, "-e")
+	}
+
+	if o.PipeFail && f.PipeFail {
+		flags = append(flags, "-o", "pipefail")
+	}
+
+	if o.NoUnset && f.NoUnset {
+		flags = append(flags, "-u")
+	}
+
+	if o.Xtrace {
+		flags = append(flags, "-x")
+	}
+
+	return flags
+}
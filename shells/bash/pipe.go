@@ -0,0 +1,39 @@
+package bash
+
+import (
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Creates a new bash command that pipes the output of each inline
+// script into the next, e.g. a | b | c, in a single bash process.
+// Each script is grouped with { } so that the whole script is
+// piped, even when it has multiple statements or ends with a
+// comment. The cleanup and name of the options apply the same as
+// for Script. Since the options enable pipefail by default, the
+// command fails when any script in the chain fails.
+//
+// Example:
+//
+//	out, err := bash.Pipe("git log --oneline", "grep fix", "wc -l").Output()
+func Pipe(scripts ...string) *exec.Cmd {
+	return NewOptions().Pipe(scripts...)
+}
+
+// Creates a new bash command that pipes the output of each inline
+// script into the next
+func (o *Options) Pipe(scripts ...string) *exec.Cmd {
+	parts := make([]string, 0, len(scripts))
+	for _, script := range scripts {
+		script = strings.TrimSpace(script)
+		if script == "" {
+			continue
+		}
+
+		// the newline ends a trailing comment before the next pipe
+		parts = append(parts, "{ "+script+"\n}")
+	}
+
+	return o.ScriptWithArgs(strings.Join(parts, " | "))
+}
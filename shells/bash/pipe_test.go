@@ -0,0 +1,56 @@
+package bash
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipe(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []struct {
+		name    string
+		scripts []string
+		want    string
+	}{
+		{"single", []string{"echo a"}, "a\n"},
+		{"chain", []string{"printf 'a\\nb\\n'", "wc -l"}, "2\n"},
+		{"trailing comment", []string{"printf 'a\\nb\\n' # two lines", "wc -l"}, "2\n"},
+		{"statements", []string{"echo a; echo b", "sort -r"}, "b\na\n"},
+		{"empty parts skipped", []string{"echo a", " ", "cat"}, "a\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Pipe(tt.scripts...).Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.TrimLeft(string(out.Stdout), " "); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPipeOptions(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	out, err := NewOptions().WithName("pipe").WithCleanup("echo cleaned >&2").Pipe("echo $0", "cat").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out.Stdout) != "pipe\n" {
+		t.Errorf("stdout = %q, want the name", out.Stdout)
+	}
+
+	if string(out.Stderr) != "cleaned\n" {
+		t.Errorf("stderr = %q, want the cleanup output", out.Stderr)
+	}
+}
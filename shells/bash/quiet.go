@@ -0,0 +1,72 @@
+package bash
+
+import (
+	"context"
+	"os"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// RunMode determines what Run and RunContext do with stdout and
+// stderr of the process.
+type RunMode int
+
+const (
+	// Stdout, stderr and stdin are inherited from the current process.
+	RunInherit RunMode = iota
+
+	// Stdout and stderr are discarded and stdin is inherited.
+	RunDiscard
+
+	// Stdout and stderr are captured into the returned output the
+	// same as Output.
+	RunCapture
+)
+
+// The default of Options.RunMode, which is RunInherit.
+var DefaultRunMode = RunInherit
+
+// Runs a new bash inline script or file with stdout and stderr
+// discarded, while still returning the exit code. It is the same
+// as Run with RunDiscard and avoids redirecting in the script.
+//
+// Example:
+//
+//	out, err := bash.RunQuiet("apt-get update")
+//	if err != nil {
+//		log.Printf("update failed with code %d", out.Code)
+//	}
+func RunQuiet(script string) (*exec.PsOutput, error) {
+	return NewOptions().RunQuiet(script)
+}
+
+// Runs the inline script or file with stdout and stderr discarded
+func (o *Options) RunQuiet(script string) (*exec.PsOutput, error) {
+	opts := *o
+	opts.RunMode = RunDiscard
+	return opts.Run(script)
+}
+
+// Sets what Run and RunContext do with stdout and stderr
+func (o *Options) WithRunMode(mode RunMode) *Options {
+	o.RunMode = mode
+	return o
+}
+
+// runs the command with stdout and stderr handled according to
+// the run mode of the options
+func (o *Options) run(ctx context.Context, cmd *exec.Cmd) (*exec.PsOutput, error) {
+	switch o.RunMode {
+	case RunDiscard:
+		cmd.Stdout = nil
+		cmd.Stderr = nil
+		cmd.Stdin = os.Stdin
+		return proc.Wait(ctx, cmd)
+	case RunCapture:
+		out, err := proc.Output(ctx, cmd)
+		return out, err
+	default:
+		return proc.Run(ctx, cmd)
+	}
+}
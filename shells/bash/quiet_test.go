@@ -0,0 +1,103 @@
+package bash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// replaces os.Stdout with a file while fn runs and returns what
+// was written to it
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	f, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+	stdout := os.Stdout
+	os.Stdout = f
+	defer func() { os.Stdout = stdout }()
+	fn()
+
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestRunMode(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []struct {
+		name      string
+		opts      func() *Options
+		inherited string
+		captured  string
+	}{
+		{"inherit", func() *Options { return NewOptions().WithRunMode(RunInherit) }, "hi\n", ""},
+		{"discard", func() *Options { return NewOptions().WithRunMode(RunDiscard) }, "", ""},
+		{"capture", func() *Options { return NewOptions().WithRunMode(RunCapture) }, "", "hi\n"},
+		{"default", func() *Options {
+			mode := DefaultRunMode
+			DefaultRunMode = RunCapture
+			defer func() { DefaultRunMode = mode }()
+			return NewOptions()
+		}, "", "hi\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var code int
+			var captured string
+			inherited := captureStdout(t, func() {
+				out, err := tt.opts().Run("echo hi; exit 2")
+				if out == nil {
+					t.Fatalf("no output, err = %v", err)
+				}
+
+				code = out.Code
+				captured = string(out.Stdout)
+			})
+
+			if code != 2 {
+				t.Errorf("code = %d, want 2", code)
+			}
+
+			if inherited != tt.inherited {
+				t.Errorf("inherited stdout = %q, want %q", inherited, tt.inherited)
+			}
+
+			if captured != tt.captured {
+				t.Errorf("captured stdout = %q, want %q", captured, tt.captured)
+			}
+		})
+	}
+}
+
+func TestRunQuiet(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	opts := NewOptions().WithRunMode(RunCapture)
+	inherited := captureStdout(t, func() {
+		out, _ := opts.RunQuiet("echo hi; exit 3")
+		if out == nil || out.Code != 3 || len(out.Stdout) != 0 {
+			t.Errorf("out = %+v, want code 3 without output", out)
+		}
+	})
+
+	if inherited != "" {
+		t.Errorf("inherited stdout = %q", inherited)
+	}
+
+	if opts.RunMode != RunCapture {
+		t.Error("RunQuiet changed the run mode of the options")
+	}
+}
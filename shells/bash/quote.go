@@ -0,0 +1,54 @@
+package bash
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Returns s quoted as a single POSIX shell word so that it can be
+// safely interpolated into script text. Strings that only contain
+// safe characters are returned unchanged, otherwise the string is
+// wrapped in single quotes with each embedded single quote
+// closing the quote, escaping the quote and reopening it. Quote
+// is meant for building script text, not for the arguments
+// passed to Command.
+//
+// Example:
+//
+//	bash.Run("rm -rf " + bash.Quote(dir))
+func Quote(s string) string {
+	if s == "" {
+		return "''"
+	}
+
+	if strings.IndexFunc(s, isUnsafe) < 0 {
+		return s
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Formats the string like fmt.Sprintf after quoting each argument
+// with Quote, so the format should only use the %s verb for
+// arguments.
+//
+// Example:
+//
+//	bash.Run(bash.Quotef("cp %s %s", src, dst))
+func Quotef(format string, args ...any) string {
+	quoted := make([]any, len(args))
+	for i, arg := range args {
+		quoted[i] = Quote(fmt.Sprint(arg))
+	}
+
+	return fmt.Sprintf(format, quoted...)
+}
+
+func isUnsafe(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	}
+
+	return !strings.ContainsRune("@%+=:,./-_", r)
+}
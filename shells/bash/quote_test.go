@@ -0,0 +1,77 @@
+package bash
+
+import "testing"
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "''"},
+		{"abc", "abc"},
+		{"a/b-c_d.e:f,g=h+i@j%k", "a/b-c_d.e:f,g=h+i@j%k"},
+		{"a b", "'a b'"},
+		{"it's", `'it'\''s'`},
+		{"'", `''\'''`},
+		{`"a"`, `'"a"'`},
+		{"$HOME", "'$HOME'"},
+		{"`date`", "'`date`'"},
+		{"$(rm -rf /)", "'$(rm -rf /)'"},
+		{"a\nb", "'a\nb'"},
+		{`a\b`, `'a\b'`},
+		{"*?[]", "'*?[]'"},
+		{"a;b|c&d", "'a;b|c&d'"},
+		{"~", "'~'"},
+		{"héllo", "'héllo'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Quote(tt.in); got != tt.want {
+				t.Errorf("Quote(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteRoundTrip(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []string{"", "a b", "it's", `"a"`, "$HOME", "`date`", "$(echo x)", "a\nb", `a\b`, "*", "-n", "a\tb"}
+	for _, in := range tests {
+		t.Run(in, func(t *testing.T) {
+			out, err := Output("printf '%s' " + Quote(in))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(out.Stdout) != in {
+				t.Errorf("got %q, want %q", out.Stdout, in)
+			}
+		})
+	}
+}
+
+func TestQuotef(t *testing.T) {
+	tests := []struct {
+		format string
+		args   []any
+		want   string
+	}{
+		{"cp %s %s", []any{"a b", "c"}, "cp 'a b' c"},
+		{"echo %s", []any{"it's"}, `echo 'it'\''s'`},
+		{"echo %s", []any{42}, "echo 42"},
+		{"echo %s", []any{""}, "echo ''"},
+		{"echo none", nil, "echo none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := Quotef(tt.format, tt.args...); got != tt.want {
+				t.Errorf("Quotef(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package bash
+
+import (
+	"io"
+	"io/fs"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Creates a new bash command that runs the named script from the
+// file system, e.g. an embed.FS. The content is written to a temp
+// file the same as ScriptFile, which adds a shebang when the
+// script has none. The temp file is removed by Cleanup.
+//
+// Example:
+//
+//	//go:embed scripts
+//	var scripts embed.FS
+//
+//	cmd, err := bash.FileFS(scripts, "scripts/install.sh")
+//	if err != nil {
+//		return err
+//	}
+//	defer bash.Cleanup(cmd)
+//	cmd.Run()
+func FileFS(fsys fs.FS, name string) (*exec.Cmd, error) {
+	return NewOptions().FileFS(fsys, name)
+}
+
+// Creates a new bash command that runs the script read from r.
+// The content is written to a temp file the same as FileFS.
+//
+// Example:
+//
+//	cmd, err := bash.ScriptReader(resp.Body)
+//	if err != nil {
+//		return err
+//	}
+//	defer bash.Cleanup(cmd)
+//	cmd.Run()
+func ScriptReader(r io.Reader) (*exec.Cmd, error) {
+	return NewOptions().ScriptReader(r)
+}
+
+// Creates a new bash command that runs the named script from
+// the file system
+func (o *Options) FileFS(fsys fs.FS, name string) (*exec.Cmd, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.tempFile(string(data))
+}
+
+// Creates a new bash command that runs the script read from r
+func (o *Options) ScriptReader(r io.Reader) (*exec.Cmd, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.tempFile(string(data))
+}
+
+// writes the script to a temp file and creates a command that runs
+// it, the temp file is removed when the command cannot be created
+func (o *Options) tempFile(script string) (*exec.Cmd, error) {
+	cmd := o.ScriptFile(script)
+	if cmd.Err != nil {
+		Cleanup(cmd)
+		return nil, cmd.Err
+	}
+
+	return cmd, nil
+}
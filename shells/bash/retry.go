@@ -0,0 +1,68 @@
+package bash
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Runs a new bash inline script or file up to attempts times while
+// it exits with a non-zero code, waiting backoff before the first
+// retry and doubling the wait for each retry after. The output of
+// the last attempt is returned with the errors of all failed
+// attempts joined, or a nil error when an attempt succeeds.
+//
+// Example:
+//
+//	out, err := bash.RunRetry("apt-get update", 3, 2*time.Second)
+func RunRetry(script string, attempts int, backoff time.Duration) (*exec.PsOutput, error) {
+	return NewOptions().RunRetryIf(script, attempts, backoff, nil)
+}
+
+// Runs a new bash inline script or file like RunRetry but only
+// retries when shouldRetry returns true for the failed output.
+// When shouldRetry is nil, every non-zero exit code is retried.
+//
+// Example:
+//
+//	out, err := bash.RunRetryIf("curl -fsSL https://example.com", 5, time.Second,
+//		func(out *exec.PsOutput) bool { return out.Code == 7 })
+func RunRetryIf(script string, attempts int, backoff time.Duration, shouldRetry func(*exec.PsOutput) bool) (*exec.PsOutput, error) {
+	return NewOptions().RunRetryIf(script, attempts, backoff, shouldRetry)
+}
+
+// Runs the inline script or file up to attempts times while
+// shouldRetry returns true for the failed output. Temp script
+// files are written again for each attempt.
+func (o *Options) RunRetryIf(script string, attempts int, backoff time.Duration, shouldRetry func(*exec.PsOutput) bool) (*exec.PsOutput, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var out *exec.PsOutput
+	errs := []error{}
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(backoff << (i - 1))
+		}
+
+		var err error
+		out, err = o.Run(script)
+		if err == nil && out.Code == 0 {
+			return out, nil
+		}
+
+		if err == nil {
+			err = fmt.Errorf("command %s failed with code %d", out.FileName, out.Code)
+		}
+
+		errs = append(errs, fmt.Errorf("attempt %d: %w", i+1, err))
+		if shouldRetry != nil && !shouldRetry(out) {
+			break
+		}
+	}
+
+	return out, errors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+package bash
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Runner runs a command in place of spawning a process.
+type Runner func(cmd *exec.Cmd) (*exec.PsOutput, error)
+
+var testRunner struct {
+	sync.RWMutex
+	fn Runner
+}
+
+// Sets a runner that is called instead of spawning a process for
+// every Run and Output function in the package, including the
+// Options methods, so that code calling bash can be unit tested.
+// The runner receives the fully constructed command, so tests can
+// assert on cmd.Path, cmd.Args, cmd.Dir and cmd.Env, and return
+// canned output. Call ResetTestRunner when the test completes.
+//
+// Example:
+//
+//	bash.SetTestRunner(func(cmd *exec.Cmd) (*exec.PsOutput, error) {
+//		if cmd.Args[len(cmd.Args)-1] != "apt-get update" {
+//			t.Errorf("unexpected args %v", cmd.Args)
+//		}
+//		return &exec.PsOutput{Stdout: []byte("ok\n")}, nil
+//	})
+//	defer bash.ResetTestRunner()
+func SetTestRunner(fn Runner) {
+	testRunner.Lock()
+	defer testRunner.Unlock()
+	testRunner.fn = fn
+}
+
+// Removes the runner set with SetTestRunner so that commands
+// spawn processes again
+func ResetTestRunner() {
+	SetTestRunner(nil)
+}
+
+// returns the context used to run commands which carries the
+// test runner when one is set
+func runContext(ctx context.Context) context.Context {
+	testRunner.RLock()
+	defer testRunner.RUnlock()
+	if testRunner.fn == nil {
+		return ctx
+	}
+
+	return proc.WithRunner(ctx, testRunner.fn)
+}
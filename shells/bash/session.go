@@ -0,0 +1,77 @@
+package bash
+
+import (
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+	"github.com/jolt9dev/go-spawn/internal/session"
+)
+
+// Session is a long-lived bash process that runs scripts one after
+// another, which avoids the cost of starting a process per script.
+// State such as variables, functions and the working directory is
+// kept between scripts. Create it with NewSession and Close it when
+// done.
+type Session struct {
+	s *session.Session
+}
+
+// Starts a new bash session. Each script sent to the session runs
+// with eval so that a syntax error fails the script rather than the
+// session. Since the session must survive failing scripts, -e and
+// -u of the options are not applied. Scripts read stdin from
+// /dev/null and calling exit ends the session.
+//
+// Example:
+//
+//	s, err := bash.NewSession()
+//	if err != nil {
+//		return err
+//	}
+//	defer s.Close()
+//
+//	s.Send("cd /tmp && export NAME=world")
+//	out, err := s.Send(`echo "hello $NAME from $PWD"`)
+func NewSession() (*Session, error) {
+	return NewOptions().NewSession()
+}
+
+// Starts a new bash session using the options
+func (o *Options) NewSession() (*Session, error) {
+	opts := *o
+	opts.ErrExit = false
+	opts.NoUnset = false
+	s, err := session.Start(opts.command("-s"), func(script, marker string) string {
+		return "eval " + Quote(script) + " </dev/null\n" +
+			"__ec=$?; printf '\\n%s %d\\n' '" + marker + "' \"$__ec\"; printf '%s\\n' '" + marker + "' >&2"
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{s: s}, nil
+}
+
+// Runs the script in the session and returns stdout with surrounding
+// whitespace trimmed. When the script exits with a non-zero code, an
+// error is returned that includes the exit code and stderr.
+func (s *Session) Send(script string) (string, error) {
+	out, err := s.SendOutput(script)
+	if err != nil {
+		return "", err
+	}
+
+	return proc.Text("bash script", out, nil)
+}
+
+// Runs the script in the session and returns the captured stdout,
+// stderr and exit code.
+func (s *Session) SendOutput(script string) (*exec.PsOutput, error) {
+	stdout, stderr, code, err := s.s.Send(script)
+	return &exec.PsOutput{Stdout: []byte(stdout), Stderr: []byte(stderr), Code: code}, err
+}
+
+// Ends the session and waits for bash to exit
+func (s *Session) Close() error {
+	return s.s.Close()
+}
@@ -0,0 +1,287 @@
+package bash
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrUnterminatedQuote        = errors.New("bash: unterminated quote")
+	ErrTrailingEscape           = errors.New("bash: trailing backslash")
+	ErrUnterminatedSubstitution = errors.New("bash: unterminated command substitution")
+)
+
+// Splits the string into arguments using bash quoting rules.
+// Single quotes are literal, double quotes allow backslash escapes
+// of $, `, ", \ and newlines, $'...' supports ANSI-C escapes such
+// as \n and \t, and a backslash outside of quotes escapes the next
+// character. Command substitutions such as $(date) and `date` and
+// parameter expansions such as ${name:-a b} are kept verbatim,
+// including nested substitutions and quotes, so they reach the
+// shell intact when the arguments are passed to -c. Nothing is
+// evaluated. An error is returned for unterminated quotes and
+// substitutions.
+//
+// Example:
+//
+//	bash.SplitArgs(`-c 'echo "a b"' c\ d`) // ["-c", "echo \"a b\"", "c d"]
+//	bash.SplitArgs(`echo $(date "+%Y %m")`) // ["echo", "$(date \"+%Y %m\")"]
+func SplitArgs(s string) ([]string, error) {
+	args := []string{}
+	token := strings.Builder{}
+	hasToken := false
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if hasToken {
+				args = append(args, token.String())
+				token.Reset()
+				hasToken = false
+			}
+
+		case c == '\\':
+			if i+1 >= len(runes) {
+				return nil, ErrTrailingEscape
+			}
+
+			i++
+			// line continuation
+			if runes[i] == '\n' {
+				continue
+			}
+
+			token.WriteRune(runes[i])
+			hasToken = true
+
+		case c == '\'':
+			end := indexRune(runes, i+1, '\'')
+			if end < 0 {
+				return nil, ErrUnterminatedQuote
+			}
+
+			token.WriteString(string(runes[i+1 : end]))
+			hasToken = true
+			i = end
+
+		case c == '$' && i+1 < len(runes) && runes[i+1] == '\'':
+			next, err := ansiC(runes, i+2, &token)
+			if err != nil {
+				return nil, err
+			}
+
+			hasToken = true
+			i = next
+
+		case c == '$' && i+1 < len(runes) && (runes[i+1] == '(' || runes[i+1] == '{'),
+			c == '`':
+			end, err := skipSubstitution(runes, i)
+			if err != nil {
+				return nil, err
+			}
+
+			token.WriteString(string(runes[i : end+1]))
+			hasToken = true
+			i = end
+
+		case c == '"':
+			next, err := doubleQuoted(runes, i+1, &token)
+			if err != nil {
+				return nil, err
+			}
+
+			hasToken = true
+			i = next
+
+		default:
+			token.WriteRune(c)
+			hasToken = true
+		}
+	}
+
+	if hasToken {
+		args = append(args, token.String())
+	}
+
+	return args, nil
+}
+
+// writes the contents of a double quoted string starting at i
+// and returns the index of the closing quote
+func doubleQuoted(runes []rune, i int, token *strings.Builder) (int, error) {
+	for ; i < len(runes); i++ {
+		c := runes[i]
+		switch c {
+		case '"':
+			return i, nil
+		case '$', '`':
+			if c == '$' && (i+1 >= len(runes) || (runes[i+1] != '(' && runes[i+1] != '{')) {
+				token.WriteRune(c)
+				continue
+			}
+
+			end, err := skipSubstitution(runes, i)
+			if err != nil {
+				return -1, err
+			}
+
+			token.WriteString(string(runes[i : end+1]))
+			i = end
+		case '\\':
+			if i+1 < len(runes) {
+				switch runes[i+1] {
+				case '$', '`', '"', '\\':
+					i++
+					token.WriteRune(runes[i])
+					continue
+				case '\n':
+					i++
+					continue
+				}
+			}
+
+			token.WriteRune(c)
+		default:
+			token.WriteRune(c)
+		}
+	}
+
+	return -1, ErrUnterminatedQuote
+}
+
+// writes the contents of a $'...' string starting at i and returns
+// the index of the closing quote
+func ansiC(runes []rune, i int, token *strings.Builder) (int, error) {
+	for ; i < len(runes); i++ {
+		c := runes[i]
+		if c == '\'' {
+			return i, nil
+		}
+
+		if c != '\\' || i+1 >= len(runes) {
+			token.WriteRune(c)
+			continue
+		}
+
+		i++
+		switch runes[i] {
+		case 'n':
+			token.WriteRune('\n')
+		case 't':
+			token.WriteRune('\t')
+		case 'r':
+			token.WriteRune('\r')
+		case 'a':
+			token.WriteRune('\a')
+		case 'b':
+			token.WriteRune('\b')
+		case 'e', 'E':
+			token.WriteRune('\x1b')
+		case 'f':
+			token.WriteRune('\f')
+		case 'v':
+			token.WriteRune('\v')
+		case '\\', '\'', '"', '?':
+			token.WriteRune(runes[i])
+		default:
+			token.WriteRune('\\')
+			token.WriteRune(runes[i])
+		}
+	}
+
+	return -1, ErrUnterminatedQuote
+}
+
+// returns the index of the rune that closes the $(...), ${...} or
+// `...` starting at i, skipping quotes and nested substitutions
+func skipSubstitution(runes []rune, i int) (int, error) {
+	if runes[i] == '`' {
+		for j := i + 1; j < len(runes); j++ {
+			switch runes[j] {
+			case '\\':
+				j++
+			case '`':
+				return j, nil
+			}
+		}
+
+		return -1, ErrUnterminatedSubstitution
+	}
+
+	open, close := runes[i+1], ')'
+	if open == '{' {
+		close = '}'
+	}
+
+	depth := 0
+	for j := i + 1; j < len(runes); j++ {
+		switch c := runes[j]; {
+		case c == '\\':
+			j++
+		case c == '\'':
+			end := indexRune(runes, j+1, '\'')
+			if end < 0 {
+				return -1, ErrUnterminatedQuote
+			}
+
+			j = end
+		case c == '"':
+			end, err := skipDoubleQuoted(runes, j+1)
+			if err != nil {
+				return -1, err
+			}
+
+			j = end
+		case c == '`', c == '$' && j+1 < len(runes) && (runes[j+1] == '(' || runes[j+1] == '{'):
+			end, err := skipSubstitution(runes, j)
+			if err != nil {
+				return -1, err
+			}
+
+			j = end
+		case c == open:
+			depth++
+		case c == close:
+			depth--
+			if depth == 0 {
+				return j, nil
+			}
+		}
+	}
+
+	return -1, ErrUnterminatedSubstitution
+}
+
+// returns the index of the quote that closes the double quoted
+// string starting at i, skipping nested substitutions
+func skipDoubleQuoted(runes []rune, i int) (int, error) {
+	for ; i < len(runes); i++ {
+		switch c := runes[i]; {
+		case c == '\\':
+			i++
+		case c == '"':
+			return i, nil
+		case c == '`', c == '$' && i+1 < len(runes) && (runes[i+1] == '(' || runes[i+1] == '{'):
+			end, err := skipSubstitution(runes, i)
+			if err != nil {
+				return -1, err
+			}
+
+			i = end
+		}
+	}
+
+	return -1, ErrUnterminatedQuote
+}
+
+func indexRune(runes []rune, start int, r rune) int {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,87 @@
+package bash
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+		err  error
+	}{
+		{"empty", "", []string{}, nil},
+		{"spaces", "  a \t b\n c ", []string{"a", "b", "c"}, nil},
+		{"single quotes", `-c 'echo "a b"'`, []string{"-c", `echo "a b"`}, nil},
+		{"single quotes are literal", `'a\nb $x'`, []string{`a\nb $x`}, nil},
+		{"double quotes", `"a b" "c\"d" "e\\f" "\$x"`, []string{"a b", `c"d`, `e\f`, "$x"}, nil},
+		{"double quotes keep other escapes", `"a\nb"`, []string{`a\nb`}, nil},
+		{"escaped space", `c\ d`, []string{"c d"}, nil},
+		{"line continuation", "a\\\nb", []string{"ab"}, nil},
+		{"adjacent quotes", `a'b c'"d"`, []string{"ab cd"}, nil},
+		{"empty quotes", `'' ""`, []string{"", ""}, nil},
+		{"ansi-c", `$'a\tb\n\'c'`, []string{"a\tb\n'c"}, nil},
+		{"command substitution", `echo $(date "+%Y %m")`, []string{"echo", `$(date "+%Y %m")`}, nil},
+		{"nested substitution", `$(echo $(echo ")"))`, []string{`$(echo $(echo ")"))`}, nil},
+		{"backticks", "a `echo b c`", []string{"a", "`echo b c`"}, nil},
+		{"parameter expansion", `${name:-a b}`, []string{"${name:-a b}"}, nil},
+		{"substitution in double quotes", `"x $(echo "a b") y"`, []string{`x $(echo "a b") y`}, nil},
+		{"unterminated single quote", `'a`, nil, ErrUnterminatedQuote},
+		{"unterminated double quote", `"a`, nil, ErrUnterminatedQuote},
+		{"unterminated ansi-c", `$'a`, nil, ErrUnterminatedQuote},
+		{"trailing escape", `a\`, nil, ErrTrailingEscape},
+		{"unterminated substitution", `$(echo a`, nil, ErrUnterminatedSubstitution},
+		{"unterminated backtick", "`echo a", nil, ErrUnterminatedSubstitution},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitArgs(tt.in)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitArgsMatchesBash(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []string{
+		`a 'b c' "d e"`,
+		`"a\"b" c\ d 'e\f'`,
+		`$'a\tb' "x\$y"`,
+	}
+
+	for _, in := range tests {
+		t.Run(in, func(t *testing.T) {
+			want, err := Output(`for a in ` + in + `; do printf '%s\0' "$a"; done`)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := SplitArgs(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			joined := ""
+			for _, a := range got {
+				joined += a + "\x00"
+			}
+
+			if joined != string(want.Stdout) {
+				t.Errorf("got %q, bash split %q", joined, want.Stdout)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package bash
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Runs each step as its own bash invocation with stdout and stderr
+// inherited from the current process and stops at the first step
+// that fails or exits with a non-zero code. The index of the failed
+// step is returned with its output and an error that names the
+// step, or -1 with the output of the last step when all succeed.
+// Unlike joining the steps with &&, each step gets a fresh shell,
+// so state such as variables or the working directory does not
+// carry over between steps.
+//
+// Example:
+//
+//	i, out, err := bash.RunSteps("apt-get update", "apt-get install -y jq", "jq --version")
+//	if err != nil {
+//		log.Fatalf("step %d failed with code %d: %v", i, out.Code, err)
+//	}
+func RunSteps(steps ...string) (failedIndex int, out *exec.PsOutput, err error) {
+	return NewOptions().RunSteps(steps...)
+}
+
+// Runs each step as its own bash invocation and stops at the
+// first step that fails
+func (o *Options) RunSteps(steps ...string) (failedIndex int, out *exec.PsOutput, err error) {
+	for i, step := range steps {
+		out, err = o.Run(step)
+		if err == nil && out.Code != 0 {
+			err = errors.New("non-zero exit code")
+		}
+
+		if err != nil {
+			code := 1
+			if out != nil {
+				code = out.Code
+			}
+
+			return i, out, fmt.Errorf("step %d exited with code %d: %w", i, code, err)
+		}
+	}
+
+	return -1, out, nil
+}
@@ -0,0 +1,59 @@
+package bash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunSteps(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []struct {
+		name   string
+		steps  []string
+		failed int
+		code   int
+		ran    []string
+		skip   []string
+	}{
+		{"all succeed", []string{"touch a", "touch b"}, -1, 0, []string{"a", "b"}, nil},
+		{"first fails", []string{"exit 3", "touch b"}, 0, 3, nil, []string{"b"}},
+		{"middle fails", []string{"touch a", "false", "touch c"}, 1, 1, []string{"a"}, []string{"c"}},
+		{"pipeline fails", []string{"touch a", "false | true", "touch c"}, 1, 1, []string{"a"}, []string{"c"}},
+		{"state does not carry over", []string{"cd /", "test ! -e \"$PWD/etc/passwd\" || exit 5", "touch c"}, -1, 0, []string{"c"}, nil},
+		{"no steps", nil, -1, 0, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			i, out, err := NewOptions().WithDir(dir).RunSteps(tt.steps...)
+			if i != tt.failed {
+				t.Fatalf("failed index = %d, want %d, err = %v", i, tt.failed, err)
+			}
+
+			if (err != nil) != (tt.failed >= 0) {
+				t.Errorf("err = %v", err)
+			}
+
+			if tt.failed >= 0 && out.Code != tt.code {
+				t.Errorf("code = %d, want %d", out.Code, tt.code)
+			}
+
+			for _, name := range tt.ran {
+				if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+					t.Errorf("step creating %s did not run", name)
+				}
+			}
+
+			for _, name := range tt.skip {
+				if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+					t.Errorf("step creating %s ran after the failure", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package bash
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-fs"
+)
+
+// When true, New and NewWithExe fail when the first argument looks
+// like a script rather than a flag or a file, e.g.
+// bash.New("echo hello") which bash would run as the script file
+// "echo hello". The error wraps ErrScriptArg and is returned when
+// the command is run. Off by default since such arguments can be
+// legitimate, e.g. a file that does not exist yet.
+var StrictArgs = false
+
+// ErrScriptArg is returned in strict mode when the first argument
+// passed to New contains whitespace, does not start with a dash
+// and is not an existing file.
+var ErrScriptArg = errors.New("bash: the first argument looks like a script, use bash.Script or bash.Command")
+
+// returns an error when strict mode is enabled and the first
+// argument looks like an inline script
+func checkArgs(args []string) error {
+	if !StrictArgs || len(args) == 0 {
+		return nil
+	}
+
+	arg := args[0]
+	if strings.HasPrefix(arg, "-") || !strings.ContainsAny(arg, " \t\n") || fs.IsFile(arg) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q", ErrScriptArg, arg)
+}
+
+// creates the command and records the strict mode error
+func newCmd(exe string, args []string) *exec.Cmd {
+	err := checkArgs(args)
+	cmd := applyDefaults(exec.New(exe, args...))
+	if err != nil {
+		cmd.Err = err
+	}
+
+	return cmd
+}
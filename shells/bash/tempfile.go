@@ -0,0 +1,146 @@
+package bash
+
+import (
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/tempfile"
+)
+
+// Inline scripts larger than this number of bytes are written to a
+// temp file and run with File instead of being passed with -c, which
+// keeps $0 and the line numbers of errors meaningful and avoids the
+// ARG_MAX limit. Only Run, Output and the other functions that run a
+// script switch to the temp file, since they remove it once the
+// command completes, even when it fails. Commands returned by Script
+// always use -c so that they leave nothing behind when run directly,
+// use ScriptFile and Cleanup for large scripts run that way. Set to
+// zero or less to always use -c. See WithScriptFileThreshold.
+var ScriptFileThreshold = 32 * 1024
+
+var tempFiles tempfile.Files
+
+// When true, the temp file written for an inline script is kept on
+// disk when the command fails so that the failure can be reproduced.
+// The path of the temp file is included in the error regardless.
+var KeepTempOnError = false
+
+// The directory temp scripts are written to. When empty, the
+// default temp directory of the os is used. The directory must
+// exist and be writable, otherwise the error is returned when the
+// command is run. Scripts are passed to bash rather than executed
+// directly, so a directory mounted with noexec works.
+var TempDir = ""
+
+// Creates a new bash command that writes the inline script to a
+// temp file with LF line endings and executes it with File. The
+// file is created with mode 0700 and starts with a shebang unless
+// the script has one. The temp file is removed by Run and Output
+// after the command completes. When running the command directly,
+// call Cleanup after it completes.
+//
+// Example:
+//
+//	cmd := bash.ScriptFile(`set -x
+//	echo "$0"`)
+//	defer bash.Cleanup(cmd)
+//	cmd.Output()
+func ScriptFile(script string) *exec.Cmd {
+	return NewOptions().ScriptFile(script)
+}
+
+// Creates a new bash command that writes the inline script to a
+// temp file and executes it with File.
+func (o *Options) ScriptFile(script string) *exec.Cmd {
+	return o.scriptFile(o.withCleanup(script), nil)
+}
+
+func (o *Options) scriptFile(script string, args []string) *exec.Cmd {
+	file, err := o.writeTempScript(script)
+	if err != nil {
+		cmd := exec.New(WhichOrDefault())
+		cmd.Err = err
+		return cmd
+	}
+
+	cmd := o.FileWithArgs(file, args...)
+	tempFiles.Track(cmd, file)
+	return cmd
+}
+
+// Sets the size in bytes above which Run, Output and the other
+// functions that run a script write inline scripts to a temp file,
+// see ScriptFileThreshold. Zero or less always uses -c.
+//
+// Example:
+//
+//	bash.NewOptions().WithScriptFileThreshold(1).Run(script)
+func (o *Options) WithScriptFileThreshold(n int) *Options {
+	o.ScriptFileThreshold = n
+	return o
+}
+
+// Sets the directory temp scripts are written to, e.g. a project
+// local .tmp directory
+func (o *Options) WithTempDir(dir string) *Options {
+	o.TempDir = dir
+	return o
+}
+
+// Sets whether the temp file written for an inline script is kept
+// on disk when the command fails
+func (o *Options) WithKeepTempOnError(keep bool) *Options {
+	o.KeepTempOnError = keep
+	return o
+}
+
+// removes the temp file of a completed command and wraps the error
+// with the path of the temp file
+func (o *Options) finish(cmd *exec.Cmd, err error) error {
+	return tempFiles.Finish(cmd, err, o.KeepTempOnError)
+}
+
+// Removes the temp file created for the command by ScriptFile,
+// if any. It is safe to call for any command.
+func Cleanup(cmd *exec.Cmd) error {
+	return tempFiles.Remove(cmd)
+}
+
+// Sets the interpreter line written at the top of temp scripts,
+// with or without the leading #!. Scripts that already start with
+// a shebang are written as is.
+//
+// Example:
+//
+//	bash.NewOptions().WithShebang("/opt/bash-5.2/bin/bash").ScriptFile(script)
+func (o *Options) WithShebang(shebang string) *Options {
+	o.Shebang = shebang
+	return o
+}
+
+// returns the interpreter line for temp scripts which is the
+// resolved bash path unless it cannot be used in a shebang
+func (o *Options) shebang() string {
+	shebang := o.Shebang
+	if shebang == "" {
+		shebang = "/usr/bin/env bash"
+		if exe := Which(); o.wslDistro() == "" && strings.HasPrefix(exe, "/") && !strings.ContainsAny(exe, " \t") {
+			shebang = exe
+		}
+	}
+
+	if !strings.HasPrefix(shebang, "#!") {
+		shebang = "#!" + shebang
+	}
+
+	return shebang
+}
+
+func (o *Options) writeTempScript(script string) (string, error) {
+	if !strings.HasPrefix(strings.TrimPrefix(script, "\ufeff"), "#!") {
+		script = o.shebang() + "\n" + script
+	}
+
+	// bash fails on CRLF line endings even under Git-Bash
+	return tempfile.Write(o.TempDir, "bash-*.sh", tempfile.LF(script), 0700)
+}
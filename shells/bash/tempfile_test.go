@@ -0,0 +1,174 @@
+package bash
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// returns a script larger than ScriptFileThreshold
+func largeScript() string {
+	return "x=" + strings.Repeat("a", ScriptFileThreshold) + "\necho ok"
+}
+
+func TestScriptLeavesNoTempFile(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []struct {
+		name string
+		run  func(o *Options, script string) error
+	}{
+		{"Script", func(o *Options, script string) error {
+			_, err := o.Script(script).Output()
+			return err
+		}},
+		{"ScriptWithArgs", func(o *Options, script string) error {
+			_, err := o.ScriptWithArgs(script, "a").Output()
+			return err
+		}},
+		{"Output", func(o *Options, script string) error {
+			_, err := o.Output(script)
+			return err
+		}},
+		{"Output failing", func(o *Options, script string) error {
+			o.Output(script + "\nexit 3")
+			return nil
+		}},
+		{"ScriptArgs", func(o *Options, script string) error {
+			o.ScriptArgs(script)
+			return nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := tt.run(NewOptions().WithTempDir(dir), largeScript()); err != nil {
+				t.Fatal(err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(entries) != 0 {
+				t.Errorf("%d files left in the temp dir, first %s", len(entries), entries[0].Name())
+			}
+		})
+	}
+}
+
+func TestOutputUsesTempFileForLargeScripts(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	out, err := NewOptions().WithTempDir(t.TempDir()).Output(largeScript() + "\necho \"$0\"")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out.Stdout)), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[1], ".sh") {
+		t.Errorf("stdout = %q, want ok and the path of the temp script", out.Stdout)
+	}
+}
+
+func TestScriptArgsPassesScriptInline(t *testing.T) {
+	script := largeScript()
+	_, args := ScriptArgs(script)
+	if len(args) < 2 || args[len(args)-2] != "-c" || args[len(args)-1] != script {
+		t.Errorf("args do not end with -c and the script: %q", args[:len(args)-1])
+	}
+}
+
+func TestTempScriptLineEndings(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"crlf", "x=a\r\necho \"$x\"\r\n", "a\n"},
+		{"crlf shebang", "#!/usr/bin/env bash\r\nx=a\r\necho \"$x\"\r\n", "a\n"},
+		{"cr in string", "printf 'a\rb'\r\n", "a\rb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cmd := NewOptions().WithTempDir(dir).ScriptFile(tt.script)
+			defer Cleanup(cmd)
+			file := cmd.Args[len(cmd.Args)-1]
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if strings.Contains(string(data), "\r\n") {
+				t.Errorf("temp script %q contains CRLF", data)
+			}
+
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(out.Stdout) != tt.want {
+				t.Errorf("stdout = %q, want %q", out.Stdout, tt.want)
+			}
+		})
+	}
+}
+
+func TestScriptFileThreshold(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	tests := []struct {
+		name      string
+		threshold int
+		script    string
+		file      bool
+		code      int
+	}{
+		{"above", 8, "echo \"$0\"\n", true, 0},
+		{"below", 1024, "echo \"$0\"\n", false, 0},
+		{"disabled", 0, "echo \"$0\"\n" + strings.Repeat("#", 64*1024), false, 0},
+		{"failing", 8, "echo \"$0\"\nexit 3", true, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			out, err := NewOptions().WithTempDir(dir).WithScriptFileThreshold(tt.threshold).Output(tt.script)
+			if tt.code == 0 && err != nil {
+				t.Fatal(err)
+			}
+
+			if out.Code != tt.code {
+				t.Errorf("code = %d, want %d", out.Code, tt.code)
+			}
+
+			if file := strings.HasSuffix(strings.TrimSpace(string(out.Stdout)), ".sh"); file != tt.file {
+				t.Errorf("$0 = %q, want a temp file %v", out.Stdout, tt.file)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(entries) != 0 {
+				t.Errorf("%d files left in the temp dir", len(entries))
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package bash
+
+import (
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/template"
+)
+
+// Creates a new bash command with the given inline script after
+// replacing each ${name} reference with the value of name in vars,
+// so values are substituted in Go rather than passed through the
+// environment. References to names that are not in vars are left
+// for the shell, as are other forms such as $var or ${var:-default}.
+// Write $${name} for a literal ${name} that must not be replaced.
+// Values are inserted as is and are not expanded again, so a value
+// that contains ${other} stays unchanged. Values are not quoted
+// either: a value from an untrusted source can inject commands, so
+// wrap such values with Quote.
+//
+// Example:
+//
+//	bash.ScriptTemplate(`tar -czf ${archive} -C ${dir} .`, map[string]string{
+//		"archive": bash.Quote(name + ".tgz"),
+//		"dir":     bash.Quote(dir),
+//	}).Run()
+func ScriptTemplate(script string, vars map[string]string) *exec.Cmd {
+	return NewOptions().ScriptTemplate(script, vars)
+}
+
+// Creates a new bash command with the given inline script after
+// replacing the ${name} references with the values in vars
+func (o *Options) ScriptTemplate(script string, vars map[string]string) *exec.Cmd {
+	return o.Script(template.Expand(script, vars))
+}
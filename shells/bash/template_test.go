@@ -0,0 +1,19 @@
+package bash
+
+import "testing"
+
+func TestScriptTemplate(t *testing.T) {
+	if Which() == "" {
+		t.Skip("bash not found")
+	}
+
+	vars := map[string]string{"greeting": Quote("it's $HOME")}
+	out, err := ScriptTemplate(`name=shell; echo ${greeting} ${name} $${greeting:-unset}`, vars).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "it's $HOME shell unset\n"; string(out.Stdout) != want {
+		t.Errorf("got %q, want %q", out.Stdout, want)
+	}
+}
@@ -0,0 +1,67 @@
+package bash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// ErrTimeout is returned by RunTimeout when the command does not
+// complete before the timeout.
+var ErrTimeout = errors.New("bash: command timed out")
+
+// The time RunTimeout waits for the process to exit after asking
+// it to terminate before the process tree is killed.
+var GracePeriod = 5 * time.Second
+
+// Runs a new bash inline script or file with stdout and stderr
+// inherited from the current process and stops it when it does not
+// complete within the timeout. On timeout, SIGTERM is sent to the
+// process group so that traps can clean up, and the process tree is
+// killed when it is still running after GracePeriod. On Windows,
+// CTRL_BREAK_EVENT is sent instead, which only reaches console
+// processes that share the console of the current process,
+// otherwise the process tree is killed right away. Scripts can
+// still read from the terminal, e.g. for a sudo prompt, see
+// RunContext. The error wraps ErrTimeout.
+//
+// Example:
+//
+//	_, err := bash.RunTimeout("./deploy.sh", 10*time.Minute)
+//	if errors.Is(err, bash.ErrTimeout) {
+//	// handle timeout
+//	}
+func RunTimeout(script string, timeout time.Duration) (*exec.PsOutput, error) {
+	return NewOptions().RunTimeout(script, timeout)
+}
+
+// Sets the time the process is given to exit after it was asked
+// to terminate by RunTimeout
+func (o *Options) WithGracePeriod(grace time.Duration) *Options {
+	o.GracePeriod = grace
+	return o
+}
+
+// Runs the inline script or file and stops it when it does not
+// complete within the timeout
+func (o *Options) RunTimeout(script string, timeout time.Duration) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	ctx, cancelTimeout := context.WithTimeout(ctx, timeout)
+	defer cancelTimeout()
+	out, err := proc.WaitGrace(ctx, cmd, o.GracePeriod)
+	if errors.Is(err, context.DeadlineExceeded) && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %s: %w", ErrTimeout, timeout, err)
+	}
+
+	return out, o.finish(cmd, err)
+}
@@ -0,0 +1,75 @@
+package bash
+
+import (
+	"errors"
+	osexec "os/exec"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-platform"
+)
+
+// ErrSudoNotFound is returned when running a command created with
+// WithUser or WithSudo and sudo is not installed.
+var ErrSudoNotFound = errors.New("bash: sudo is required to run as another user but was not found")
+
+// ErrUserNotSupported is returned when running a command created
+// with WithUser or WithSudo on Windows outside of a WSL
+// distribution, which has no equivalent of sudo.
+var ErrUserNotSupported = errors.New("bash: running as another user is only supported on Windows with a WSL distribution")
+
+// Creates new options from the package level defaults that run
+// bash as the given user with sudo -u <user>. sudo may prompt for a
+// password on the inherited stdin unless sudoers allows it without
+// one. sudo resets the environment according to sudoers, so
+// variables set with WithEnv may not reach the script, and the user
+// must be able to read script files, including the temp files of
+// inline scripts larger than ScriptFileThreshold. With a WSL
+// distribution, wsl.exe -u <user> is used instead.
+//
+// Example:
+//
+//	bash.WithUser("postgres").Run("psql -c 'select version()'")
+func WithUser(username string) *Options {
+	return NewOptions().WithUser(username)
+}
+
+// Creates new options from the package level defaults that run
+// bash as root with sudo, see WithUser.
+//
+// Example:
+//
+//	bash.WithSudo().Run("apt-get update")
+func WithSudo() *Options {
+	return NewOptions().WithSudo()
+}
+
+// Sets the user bash runs as with sudo -u
+func (o *Options) WithUser(username string) *Options {
+	o.User = username
+	return o
+}
+
+// Sets bash to run as root with sudo
+func (o *Options) WithSudo() *Options {
+	o.User = "root"
+	return o
+}
+
+// creates the command that runs bash as the user of the options
+// with sudo or records why it cannot
+func (o *Options) sudo(exe string, args []string) *exec.Cmd {
+	sudo, err := osexec.LookPath("sudo")
+	if platform.IsWindows() {
+		err = ErrUserNotSupported
+	} else if err != nil {
+		err = ErrSudoNotFound
+	}
+
+	if err != nil {
+		cmd := exec.New(exe, args...)
+		cmd.Err = err
+		return cmd
+	}
+
+	return exec.New(sudo, append([]string{"-u", o.User, "--", exe}, args...)...)
+}
@@ -0,0 +1,44 @@
+package bash
+
+import (
+	"fmt"
+	osexec "os/exec"
+	"strings"
+
+	"github.com/jolt9dev/go-spawn/internal/version"
+)
+
+var versions version.Cache
+
+// Returns the version of the resolved bash such as 5.2.21 from
+// bash --version. The version is cached per executable for the
+// lifetime of the process. When bash cannot be found, an
+// *ErrShellNotFound error is returned.
+//
+// Example:
+//
+//	v, err := bash.Version()
+//	if err == nil && strings.HasPrefix(v, "3.") {
+//		log.Println("bash 3 does not support associative arrays")
+//	}
+func Version() (string, error) {
+	exe, err := WhichE()
+	if err != nil {
+		return "", err
+	}
+
+	return versions.Get(exe, func() (string, error) {
+		out, err := osexec.Command(exe, "--version").Output()
+		if err != nil {
+			return "", fmt.Errorf("bash: %s --version failed: %w", exe, err)
+		}
+
+		line, _, _ := strings.Cut(string(out), "\n")
+		v, ok := version.Parse(line)
+		if !ok {
+			return "", fmt.Errorf("bash: unable to parse the version from %q", strings.TrimSpace(line))
+		}
+
+		return v, nil
+	})
+}
@@ -0,0 +1,31 @@
+package bash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWhichQuotedPathWithSpaces(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Program Files", "Git", "bin")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	exe := filepath.Join(dir, "bash")
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("BASH_PATH", `"`+exe+`"`)
+	ResetWhichCache()
+	t.Cleanup(ResetWhichCache)
+
+	if got := WhichFromEnv(); got != exe {
+		t.Errorf("WhichFromEnv() = %q, want %q", got, exe)
+	}
+
+	if got := Which(); got != exe {
+		t.Errorf("Which() = %q, want %q", got, exe)
+	}
+}
@@ -0,0 +1,64 @@
+package bash
+
+import (
+	"context"
+	"io"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Runs a new bash inline script or file with stdout and stderr
+// written to the given writers as the process produces them, so
+// nothing is buffered in memory. A nil writer discards the stream.
+// The returned output has the exit code but no captured streams.
+//
+// Example:
+//
+//	logFile, _ := os.Create("build.log")
+//	defer logFile.Close()
+//	out, err := bash.RunTo("make build", io.MultiWriter(os.Stdout, logFile), os.Stderr)
+func RunTo(script string, stdout, stderr io.Writer) (*exec.PsOutput, error) {
+	return NewOptions().RunTo(script, stdout, stderr)
+}
+
+// Runs the inline script or file with stdout and stderr written
+// to the given writers
+func (o *Options) RunTo(script string, stdout, stderr io.Writer) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Wait(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
+
+// Outputs a new bash inline script or file into the given writers
+// and returns the exit code. Unlike Output, no buffers are allocated
+// for the streams, so callers can reuse their own buffers or write
+// into a structured logger. It behaves the same as RunTo, which
+// returns the full exec.PsOutput without captured streams instead
+// of just the exit code. A nil writer discards the stream.
+//
+// Example:
+//
+//	var stdout, stderr bytes.Buffer
+//	code, err := bash.OutputTo("make build", &stdout, &stderr)
+//	if err != nil || code != 0 {
+//		log.Printf("build failed with code %d: %s", code, stderr.String())
+//	}
+func OutputTo(script string, stdout, stderr io.Writer) (code int, err error) {
+	return NewOptions().OutputTo(script, stdout, stderr)
+}
+
+// Outputs the inline script or file into the given writers and
+// returns the exit code
+func (o *Options) OutputTo(script string, stdout, stderr io.Writer) (code int, err error) {
+	out, err := o.RunTo(script, stdout, stderr)
+	if out == nil {
+		return 1, err
+	}
+
+	return out.Code, err
+}
@@ -0,0 +1,283 @@
+package bash
+
+import (
+	"context"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/jolt9dev/go-env"
+	"github.com/jolt9dev/go-fs"
+	"github.com/jolt9dev/go-platform"
+	"github.com/jolt9dev/go-xstrings"
+)
+
+// The WSL distribution used to run bash on Windows when
+// none is set on the options. When empty, the System32
+// bash.exe runs the default distribution.
+var DefaultWslDistro = ""
+
+// The directory WSL mounts windows drives under which is used
+// when translating windows paths such as C:\path to /mnt/c/path.
+// Change it when automount.root is configured in wsl.conf.
+var WslMountRoot = "/mnt/"
+
+// When true, arguments passed to New and Command that are
+// absolute windows paths are translated with TranslatePath
+// when bash runs inside WSL. Set to false when arguments
+// should be passed through untouched.
+var TranslateArgs = true
+
+// how long wsl.exe -l -q may run before HasWslDistro gives up,
+// e.g. when the WSL service hangs while starting
+var wslListTimeout = 10 * time.Second
+
+var wslState struct {
+	sync.Mutex
+	installed     bool
+	exe           string
+	distro        bool
+	distroChecked bool
+}
+
+// Reports whether the Windows Subsystem for Linux is installed,
+// i.e. System32\wsl.exe exists. Always false on non-Windows
+// platforms. The result is cached once WSL is found, otherwise
+// the file system is checked again on the next call so that WSL
+// installed while the process runs is detected.
+//
+// Example:
+//
+//	if bash.IsWslInstalled() && bash.Backend() == bash.Wsl {
+//		log.Println("bash runs inside WSL, paths are translated to /mnt")
+//	}
+func IsWslInstalled() bool {
+	installed, _ := detectWsl()
+	return installed
+}
+
+// returns whether WSL is installed and the path to wsl.exe
+func detectWsl() (bool, string) {
+	if !platform.IsWindows() {
+		return false, ""
+	}
+
+	wslState.Lock()
+	defer wslState.Unlock()
+	if wslState.installed {
+		return true, wslState.exe
+	}
+
+	root := env.Get("SystemRoot")
+	if root == "" {
+		root = "C:\\Windows"
+	}
+
+	fp := filepath.Join(root, "System32", "wsl.exe")
+	if fs.IsFile(fp) {
+		wslState.installed = true
+		wslState.exe = fp
+	}
+
+	return wslState.installed, wslState.exe
+}
+
+// Reports whether WSL has at least one distribution installed,
+// i.e. wsl.exe -l -q lists one. The System32 bash.exe fails with an
+// unclear message without one, so commands run with it fail with
+// ErrNoWslDistro instead. Always false when WSL is not installed.
+// wsl.exe is given a few seconds to list them and no distribution
+// is assumed when it fails or times out. The result is cached
+// either way.
+//
+// Example:
+//
+//	if bash.IsWslInstalled() && !bash.HasWslDistro() {
+//		log.Println("install a distribution with wsl --install")
+//	}
+func HasWslDistro() bool {
+	installed, exe := detectWsl()
+	if !installed {
+		return false
+	}
+
+	wslState.Lock()
+	if wslState.distroChecked {
+		defer wslState.Unlock()
+		return wslState.distro
+	}
+	wslState.Unlock()
+
+	// wsl.exe runs outside the lock so that a slow WSL service
+	// does not block other callers past the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), wslListTimeout)
+	defer cancel()
+
+	out, err := osexec.CommandContext(ctx, exe, "-l", "-q").Output()
+	distro := err == nil && hasWslDistro(out)
+
+	wslState.Lock()
+	defer wslState.Unlock()
+	wslState.distro = distro
+	wslState.distroChecked = true
+	return distro
+}
+
+// reports whether the output of wsl.exe -l -q lists a distribution
+func hasWslDistro(out []byte) bool {
+	// wsl.exe writes UTF-16LE, dropping the NUL bytes and the byte
+	// order mark leaves the ASCII names while any other byte still
+	// counts as a distribution
+	text := strings.ReplaceAll(string(out), "\x00", "")
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "\xff\xfe"), "\ufeff")
+	return strings.TrimSpace(text) != ""
+}
+
+// Creates new options from the package level defaults
+// that run bash in the given WSL distribution using
+// wsl.exe -d <name>.
+//
+// Example:
+//
+//	bash.WithWslDistro("Ubuntu-24.04").Run("uname -a")
+func WithWslDistro(name string) *Options {
+	return NewOptions().WithWslDistro(name)
+}
+
+// Sets the WSL distribution used to run bash on Windows
+func (o *Options) WithWslDistro(name string) *Options {
+	o.WslDistro = name
+	return o
+}
+
+func (o *Options) wslDistro() string {
+	if o.WslDistro != "" {
+		return o.WslDistro
+	}
+
+	return DefaultWslDistro
+}
+
+// reports whether the command runs bash inside WSL which requires
+// windows paths to be translated to /mnt/<drive> paths.
+func (o *Options) useWsl() bool {
+	if !IsWslInstalled() {
+		return false
+	}
+
+	if o.wslDistro() != "" {
+		return true
+	}
+
+	return isWslBash(WhichOrDefault())
+}
+
+// reports whether exe is the System32 bash.exe which runs
+// bash inside the default WSL distribution
+func isWslBash(exe string) bool {
+	return IsWslInstalled() && xstrings.HasSuffixFold(exe, "System32\\bash.exe")
+}
+
+// Translates a windows path to the path of the same file inside
+// WSL such as C:\path to /mnt/c/path. Relative paths, including
+// drive relative paths such as C:path, are made absolute first
+// and forward slashes are accepted as separators. UNC paths to
+// the WSL file system such as \\wsl$\Ubuntu\home are translated
+// to the path inside the distribution. Paths that are already
+// POSIX style and other UNC paths such as \\server\share are
+// returned unchanged.
+//
+// Example:
+//
+//	bash.TranslatePath("C:\\Users\\me\\script.sh") // /mnt/c/Users/me/script.sh
+func TranslatePath(p string) string {
+	// UNC paths may use forward slashes too, e.g. //wsl$/Ubuntu
+	if isUNC(p) {
+		if wp, ok := wslSharePath(p); ok {
+			return wp
+		}
+
+		return p
+	}
+
+	if p == "" || strings.HasPrefix(p, "/") {
+		return p
+	}
+
+	if !isWindowsAbs(p) {
+		abs, err := filepath.Abs(p)
+		if err == nil {
+			p = abs
+		}
+	}
+
+	p = strings.ReplaceAll(p, "\\", "/")
+	if !hasDrive(p) {
+		return p
+	}
+
+	root := strings.TrimSuffix(WslMountRoot, "/") + "/"
+	drive := string(unicode.ToLower(rune(p[0])))
+	rest := strings.TrimLeft(p[2:], "/")
+	if rest == "" {
+		return root + drive
+	}
+
+	return root + drive + "/" + rest
+}
+
+// translates the arguments that are absolute windows paths
+func translateArgs(args []string) []string {
+	next := make([]string, len(args))
+	for i, arg := range args {
+		if isWindowsAbs(arg) {
+			arg = TranslatePath(arg)
+		}
+
+		next[i] = arg
+	}
+
+	return next
+}
+
+func hasDrive(p string) bool {
+	if len(p) < 2 || p[1] != ':' {
+		return false
+	}
+
+	c := p[0]
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// reports whether p is an absolute path with a drive letter
+// such as C:\path or C:/path
+func isWindowsAbs(p string) bool {
+	return hasDrive(p) && len(p) > 2 && (p[2] == '\\' || p[2] == '/')
+}
+
+func isUNC(p string) bool {
+	return strings.HasPrefix(p, "\\\\") || strings.HasPrefix(p, "//")
+}
+
+// returns the path inside the distribution for UNC paths to the
+// WSL file system such as \\wsl$\<distro>\path or
+// \\wsl.localhost\<distro>\path
+func wslSharePath(p string) (string, bool) {
+	parts := strings.SplitN(strings.ReplaceAll(p[2:], "\\", "/"), "/", 3)
+	if len(parts) < 2 || parts[1] == "" {
+		return "", false
+	}
+
+	if !strings.EqualFold(parts[0], "wsl$") && !strings.EqualFold(parts[0], "wsl.localhost") {
+		return "", false
+	}
+
+	if len(parts) == 2 {
+		return "/", true
+	}
+
+	return "/" + strings.TrimLeft(parts[2], "/"), true
+}
@@ -0,0 +1,147 @@
+package bash
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jolt9dev/go-platform"
+)
+
+func TestTranslatePathMountRoot(t *testing.T) {
+	tests := []struct {
+		root string
+		path string
+		want string
+	}{
+		{"/mnt/", `C:\Users\me\script.sh`, "/mnt/c/Users/me/script.sh"},
+		{"/mnt", `C:\Users\me\script.sh`, "/mnt/c/Users/me/script.sh"},
+		{"/", `D:\data`, "/d/data"},
+		{"", `D:\data`, "/d/data"},
+		{"/win/", `e:\`, "/win/e"},
+		{"/win", `E:\a\b\`, "/win/e/a/b/"},
+		{"/win/", "/home/me", "/home/me"},
+	}
+
+	root := WslMountRoot
+	t.Cleanup(func() { WslMountRoot = root })
+
+	for _, tt := range tests {
+		t.Run(tt.root+" "+tt.path, func(t *testing.T) {
+			WslMountRoot = tt.root
+			if got := TranslatePath(tt.path); got != tt.want {
+				t.Errorf("TranslatePath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslatePathSeparators(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"C:/Users/me/script.sh", "/mnt/c/Users/me/script.sh"},
+		{`C:\Users/me\script.sh`, "/mnt/c/Users/me/script.sh"},
+		{"c:/", "/mnt/c"},
+		{`C:\\a\\\b`, "/mnt/c/a///b"},
+		{"/already/posix", "/already/posix"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := TranslatePath(tt.path); got != tt.want {
+				t.Errorf("TranslatePath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslatePathRelative(t *testing.T) {
+	tests := []string{"script.sh", "dir/script.sh", `dir\script.sh`, "../script.sh"}
+	if platform.IsWindows() {
+		tests = append(tests, "C:script.sh")
+	}
+
+	for _, path := range tests {
+		t.Run(path, func(t *testing.T) {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := strings.ReplaceAll(abs, `\`, "/")
+			if platform.IsWindows() {
+				want = TranslatePath(abs)
+			}
+
+			got := TranslatePath(path)
+			if !strings.HasPrefix(got, "/") {
+				t.Errorf("TranslatePath(%q) = %q, want an absolute posix path", path, got)
+			}
+
+			if got != want {
+				t.Errorf("TranslatePath(%q) = %q, want %q", path, got, want)
+			}
+		})
+	}
+}
+
+func TestTranslateArgs(t *testing.T) {
+	args := []string{"-c", `C:\a\b`, "rel/path", "C:/x", "C:rel", `\\server\share`}
+	want := []string{"-c", "/mnt/c/a/b", "rel/path", "/mnt/c/x", "C:rel", `\\server\share`}
+	if got := translateArgs(args); !slices.Equal(got, want) {
+		t.Errorf("translateArgs(%q) = %q, want %q", args, got, want)
+	}
+}
+
+func TestTranslatePathUNC(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{`\\wsl$\Ubuntu\home\me\script.sh`, "/home/me/script.sh"},
+		{`\\wsl.localhost\Ubuntu\home\me`, "/home/me"},
+		{`\\WSL$\Ubuntu\etc\`, "/etc/"},
+		{`\\wsl$\Ubuntu`, "/"},
+		{`\\wsl$\Ubuntu\`, "/"},
+		{"//wsl$/Ubuntu/home/me", "/home/me"},
+		{`\\wsl$`, `\\wsl$`},
+		{`\\wsl$\`, `\\wsl$\`},
+		{`\\server\share\script.sh`, `\\server\share\script.sh`},
+		{"//server/share/script.sh", "//server/share/script.sh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := TranslatePath(tt.path); got != tt.want {
+				t.Errorf("TranslatePath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasWslDistroOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"bom only", "\xff\xfe", false},
+		{"blank lines", "\r\x00\n\x00", false},
+		{"utf16 name", "\xff\xfeU\x00b\x00u\x00n\x00t\x00u\x00\r\x00\n\x00", true},
+		{"utf8 name", "\ufeffDebian\r\n", true},
+		{"non ascii name", "\xff\xfe\xe9\x00", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasWslDistro([]byte(tt.out)); got != tt.want {
+				t.Errorf("hasWslDistro(%q) = %v, want %v", tt.out, got, tt.want)
+			}
+		})
+	}
+}
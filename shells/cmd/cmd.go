@@ -0,0 +1,214 @@
+// Package cmd runs Windows cmd.exe inline scripts and batch files.
+//
+// cmd.exe does not parse its command line using the same rules as
+// other programs, so on Windows the command line is passed verbatim
+// using /s /c "..." instead of quoting each argument.
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/lookup"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+	"github.com/jolt9dev/go-spawn/internal/tempfile"
+)
+
+var tempFiles tempfile.Files
+
+func init() {
+	exec.Register("cmd", &exec.Executable{
+		Name:     "cmd",
+		Variable: "CMD_PATH",
+		Windows: []string{
+			"${ComSpec}",
+			"${SystemRoot}\\System32\\cmd.exe",
+		},
+	})
+}
+
+// Returns the path to the cmd executable or an empty string.
+// The path is resolved in order from CMD_PATH, which may be quoted
+// and contain variables such as ${SystemRoot}, then the known locations
+// and then the PATH.
+func Which() string {
+	if exe := WhichFromEnv(); exe != "" {
+		return exe
+	}
+
+	exe, _ := exec.Find("cmd")
+	return exe
+}
+
+// Returns the unquoted and expanded path from CMD_PATH when it
+// is an existing file, otherwise an empty string.
+//
+// Example:
+//
+//	if cmd.WhichFromEnv() == "" {
+//		log.Println("CMD_PATH is not set, probing for cmd")
+//	}
+func WhichFromEnv() string {
+	return lookup.FromEnv("CMD_PATH")
+}
+
+// Returns the path to the cmd executable or the default
+// which is the name of the executable without a path or
+// extension when it is not found.
+func WhichOrDefault() string {
+	exe := Which()
+	if exe == "" {
+		return "cmd"
+	}
+
+	return exe
+}
+
+// Returns the locations probed for cmd on the current os
+// in order with environment variables expanded, starting with
+// CMD_PATH when set. PATH is searched when none of them exist.
+//
+// Example:
+//
+//	fmt.Println("searched:", strings.Join(cmd.Candidates(), ", "))
+func Candidates() []string {
+	return lookup.Candidates("cmd")
+}
+
+// Creates a new cmd command with the given arguments
+// using vardiac arguments
+//
+// Example:
+//
+//	cmd.New("/d", "/c", "echo hello").Run()
+func New(args ...string) *exec.Cmd {
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Creates a new cmd command with the given arguments
+// using a single string that is split using cmd
+// quoting rules
+//
+// Example:
+//
+//	cmd.Command(`/d /c "echo hello"`).Run()
+func Command(args string) *exec.Cmd {
+	return exec.New(WhichOrDefault(), SplitArgs(args)...)
+}
+
+// Creates a new cmd command with the given batch file which
+// is invoked with call so that the exit code of the process is
+// the %ERRORLEVEL% set by the batch file.
+//
+// Example:
+//
+//	cmd.File("script.cmd").Run()
+func File(file string) *exec.Cmd {
+	return line("call \"" + file + "\"")
+}
+
+// Creates a new cmd command with the given inline script
+// or file. However, the file must have a .bat or .cmd extension
+// and be on a single line. Since cmd /c only runs a single line,
+// multiline scripts are written to a temp .cmd file with CRLF line
+// endings and @echo off, so that blocks, ^ continuations, labels
+// and goto work the same as in a batch file. The script runs as a
+// subroutine of the temp file, which deletes itself once the
+// script returns, so the command can be run directly. Only a
+// script that calls exit without /b leaves the file behind, which
+// Run, Output and Cleanup still remove.
+//
+// Example:
+//
+//	cmd.Script(`mkdir build
+//	cd build`).WithCwd("C:\\path\\to\\dir").Run()
+//	cmd.Script("C:\\path\\to\\script.cmd").Output()
+func Script(script string) *exec.Cmd {
+	if !strings.ContainsAny(script, "\n") {
+		script = strings.TrimSpace(script)
+
+		lower := strings.ToLower(script)
+		if strings.HasSuffix(lower, ".bat") || strings.HasSuffix(lower, ".cmd") {
+			return File(script)
+		}
+
+		return line(script)
+	}
+
+	file, err := tempfile.Write("", "cmd-*.cmd", tempfile.CRLF(batch(script)), 0600)
+	if err != nil {
+		c := exec.New(WhichOrDefault())
+		c.Err = err
+		return c
+	}
+
+	c := File(file)
+	tempFiles.Track(c, file)
+	return c
+}
+
+// Removes the temp file created for a multiline script by Script,
+// if any, e.g. when the script called exit without /b. It is safe
+// to call for any command.
+func Cleanup(cmd *exec.Cmd) error {
+	return tempFiles.Remove(cmd)
+}
+
+// Run a new cmd inline script or file.
+// When using a file, the file must have a .bat or .cmd extension
+// and be on a single line.
+// Run will set stdout and stderr to inherit and not
+// capture the output. The exit code is the %ERRORLEVEL%
+// of the script.
+//
+// Example:
+//
+//	cmd.Run("C:\\path\\to\\script.cmd")
+func Run(script string) (*exec.PsOutput, error) {
+	c := Script(script)
+	out, err := proc.Run(context.Background(), c)
+	return out, tempFiles.Finish(c, err, false)
+}
+
+// Output a new cmd inline script or file.
+// When using a file, the file must have a .bat or .cmd extension
+// and be on a single line.
+// Output will set stdout and stderr to piped and captures
+// the standard output and error streams. The exit code
+// is the %ERRORLEVEL% of the script.
+//
+// Example:
+//
+//	out, err := cmd.Output("C:\\path\\to\\script.cmd")
+//	if err != nil || out.Code != 0 {
+//	// handle error
+//	}
+func Output(script string) (*exec.PsOutput, error) {
+	c := Script(script)
+	out, err := proc.Output(context.Background(), c)
+	return out, tempFiles.Finish(c, err, false)
+}
+
+// returns the batch file for a multiline script. The script runs as
+// a subroutine so that exit /b returns to the file, which then
+// deletes itself: (goto) ends the batch context, so del does not
+// fail on the running file, and the errorlevel of the script is
+// expanded before that.
+func batch(script string) string {
+	return "@echo off\n" +
+		"call :__spawn_main\n" +
+		"(goto) 2>nul & del \"%~f0\" & exit /b %errorlevel%\n" +
+		":__spawn_main\n" +
+		script + "\n"
+}
+
+// creates a command that runs the line with /d /s /c. On windows
+// the command line is set verbatim so that the line is not quoted
+// using the rules for other programs.
+func line(script string) *exec.Cmd {
+	exe := WhichOrDefault()
+	c := exec.New(exe, "/d", "/s", "/c", script)
+	setCmdLine(c, "\""+exe+"\" /d /s /c \""+script+"\"")
+	return c
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// returns the temp file the command calls
+func calledFile(t *testing.T, args []string) string {
+	t.Helper()
+	last := args[len(args)-1]
+	if !strings.HasPrefix(last, `call "`) {
+		t.Fatalf("the command does not call a file: %q", args)
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(last, `call "`), `"`)
+}
+
+func TestScriptMultilineWritesBatchFile(t *testing.T) {
+	script := "if 1==1 (\n  echo yes\n) else (\n  echo no\n)\necho a ^\nb"
+	c := Script(script)
+	file := calledFile(t, c.Args)
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "@echo off\r\ncall :__spawn_main\r\n(goto) 2>nul & del \"%~f0\" & exit /b %errorlevel%\r\n:__spawn_main\r\nif 1==1 (\r\n  echo yes\r\n) else (\r\n  echo no\r\n)\r\necho a ^\r\nb\r\n"
+	if string(data) != want {
+		t.Errorf("batch file = %q, want %q", data, want)
+	}
+
+	if err := Cleanup(c); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("Cleanup left %s on disk", file)
+	}
+}
+
+func TestScriptSingleLine(t *testing.T) {
+	tests := []struct {
+		script string
+		want   string
+	}{
+		{"echo hello", "echo hello"},
+		{"  build.cmd  ", `call "build.cmd"`},
+		{`C:\scripts\setup.BAT`, `call "C:\scripts\setup.BAT"`},
+	}
+
+	for _, tt := range tests {
+		c := Script(tt.script)
+		if got := c.Args[len(c.Args)-1]; got != tt.want {
+			t.Errorf("Script(%q) runs %q, want %q", tt.script, got, tt.want)
+		}
+	}
+}
+
+func TestOutputMultiline(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("cmd.exe only runs on windows")
+	}
+
+	out, err := Output("set n=1\nif %n%==1 (\n  echo one\n)\ngoto end\necho skipped\n:end\necho done")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.ReplaceAll(string(out.Stdout), "\r\n", "\n"); got != "one\ndone\n" {
+		t.Errorf("stdout = %q", got)
+	}
+}
+
+func TestOutputExitCode(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("cmd.exe only runs on windows")
+	}
+
+	tests := []struct {
+		name   string
+		script string
+		code   int
+	}{
+		{"single line", "exit /b 5", 5},
+		{"multiline", "echo a\nexit /b 5", 5},
+		{"multiline last command", "echo a\ncmd /c exit 7", 7},
+		{"success", "echo a\necho b", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, _ := Output(tt.script)
+			if out == nil || out.Code != tt.code {
+				t.Errorf("out = %+v, want code %d", out, tt.code)
+			}
+		})
+	}
+}
+
+func TestScriptMultilineDeletesItself(t *testing.T) {
+	if runtime.GOOS != "windows" {
+		t.Skip("cmd.exe only runs on windows")
+	}
+
+	c := Script("echo a\nexit /b 3")
+	file := calledFile(t, c.Args)
+	c.Output()
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		Cleanup(c)
+		t.Errorf("running the command directly left %s on disk", file)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !windows
+
+package cmd
+
+import "github.com/jolt9dev/go-exec"
+
+func setCmdLine(c *exec.Cmd, line string) {}
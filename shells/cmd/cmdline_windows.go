@@ -0,0 +1,17 @@
+//go:build windows
+
+package cmd
+
+import (
+	"syscall"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+func setCmdLine(c *exec.Cmd, line string) {
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	c.SysProcAttr.CmdLine = line
+}
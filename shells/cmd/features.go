@@ -0,0 +1,16 @@
+package cmd
+
+import "github.com/jolt9dev/go-spawn/shells"
+
+// Returns the features of cmd, which supports none of them. Use
+// the exit code of each command, e.g. with || exit /b, instead of
+// -e.
+//
+// Example:
+//
+//	if !cmd.Features().ErrExit {
+//		cmd.Run("make build || exit /b 1")
+//	}
+func Features() shells.ShellFeatures {
+	return shells.ShellFeatures{}
+}
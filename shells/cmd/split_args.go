@@ -0,0 +1,46 @@
+package cmd
+
+import "strings"
+
+// Splits the string into arguments using cmd quoting rules.
+// Double quotes group text with spaces and the caret escapes
+// the next character outside of quotes. Single quotes have
+// no special meaning.
+//
+// Example:
+//
+//	cmd.SplitArgs(`/c "echo a b" ^"c^"`) // ["/c", "echo a b", "\"c\""]
+func SplitArgs(s string) []string {
+	args := []string{}
+	token := strings.Builder{}
+	inQuote := false
+	hasToken := false
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+			hasToken = true
+		case c == '^' && !inQuote && i+1 < len(runes):
+			i++
+			token.WriteRune(runes[i])
+			hasToken = true
+		case (c == ' ' || c == '\t' || c == '\r' || c == '\n') && !inQuote:
+			if hasToken {
+				args = append(args, token.String())
+				token.Reset()
+				hasToken = false
+			}
+		default:
+			token.WriteRune(c)
+			hasToken = true
+		}
+	}
+
+	if hasToken {
+		args = append(args, token.String())
+	}
+
+	return args
+}
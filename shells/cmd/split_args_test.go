@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"empty", "", []string{}},
+		{"spaces", "  a \t b\r\n c ", []string{"a", "b", "c"}},
+		{"double quotes", `/c "echo a b"`, []string{"/c", "echo a b"}},
+		{"caret escapes", `^"c^" a^&b a^ b`, []string{`"c"`, "a&b", "a b"}},
+		{"caret in quotes", `"a^b"`, []string{"a^b"}},
+		{"trailing caret", `a^`, []string{"a^"}},
+		{"single quotes", `'a b'`, []string{"'a", "b'"}},
+		{"windows path", `C:\Program Files\app.exe`, []string{`C:\Program`, `Files\app.exe`}},
+		{"quoted path", `"C:\Program Files\app.exe" /q`, []string{`C:\Program Files\app.exe`, "/q"}},
+		{"adjacent quotes", `a"b c"d`, []string{"ab cd"}},
+		{"empty quotes", `""`, []string{""}},
+		{"unterminated quote", `"a b`, []string{"a b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitArgs(tt.in); !slices.Equal(got, tt.want) {
+				t.Errorf("SplitArgs(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Package dash runs inline scripts and files with the Debian
+// Almquist shell, which is /bin/sh on Debian and Ubuntu. Since
+// dash only supports POSIX features, running scripts with it is a
+// cheap way to catch bashisms in scripts meant for sh.
+package dash
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/lookup"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+func init() {
+	exec.Register("dash", &exec.Executable{
+		Name:     "dash",
+		Variable: "DASH_PATH",
+		Linux: []string{
+			"/bin/dash",
+			"/usr/bin/dash",
+		},
+	})
+}
+
+// Returns the path to the dash executable or an empty string.
+// The path is resolved in order from DASH_PATH, which may be quoted
+// and contain variables such as ${HOME}, then the known locations
+// and then the PATH.
+func Which() string {
+	if exe := WhichFromEnv(); exe != "" {
+		return exe
+	}
+
+	exe, _ := exec.Find("dash")
+	return exe
+}
+
+// Returns the unquoted and expanded path from DASH_PATH when it
+// is an existing file, otherwise an empty string.
+//
+// Example:
+//
+//	if dash.WhichFromEnv() == "" {
+//		log.Println("DASH_PATH is not set, probing for dash")
+//	}
+func WhichFromEnv() string {
+	return lookup.FromEnv("DASH_PATH")
+}
+
+// Returns the path to the dash executable or the default
+// which is the name of the executable without a path or
+// extension when it is not found.
+func WhichOrDefault() string {
+	exe := Which()
+	if exe == "" {
+		return "dash"
+	}
+
+	return exe
+}
+
+// Returns the locations probed for dash on the current os
+// in order with environment variables expanded, starting with
+// DASH_PATH when set. PATH is searched when none of them exist.
+//
+// Example:
+//
+//	fmt.Println("searched:", strings.Join(dash.Candidates(), ", "))
+func Candidates() []string {
+	return lookup.Candidates("dash")
+}
+
+// Creates a new dash command with the given arguments
+// using vardiac arguments
+//
+// Example:
+//
+//	dash.New("-e", "-c", "echo hello").Run()
+func New(args ...string) *exec.Cmd {
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Creates a new dash command with the given arguments
+// using a single string
+//
+// Example:
+//
+//	dash.Command("-e -c 'echo hello'").Run()
+func Command(args string) *exec.Cmd {
+	return exec.New(WhichOrDefault(), exec.SplitArgs(args)...)
+}
+
+// Creates a new dash command with the given script file
+//
+// Example:
+//
+//	dash.File("script.sh").Run()
+func File(file string) *exec.Cmd {
+	args := []string{"-e", file}
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Creates a new dash command with the given inline script
+// or file. However, the file must have a .sh extension
+// and be on a single line. dash does not support pipefail,
+// so only -e is set.
+//
+// Example:
+//
+//	dash.Script(`apt-get install -y \
+//	  curl \
+//	  zip`).WithCwd("/path/to/dir").Run()
+//	dash.Script("/path/to/script.sh").Output()
+func Script(script string) *exec.Cmd {
+	if !strings.ContainsAny(script, "\n") {
+		script = strings.TrimSpace(script)
+
+		if strings.HasSuffix(script, ".sh") {
+			return File(script)
+		}
+	}
+
+	args := []string{"-e", "-c", script}
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Run a new dash inline script or file.
+// When using a file, the file must have a .sh extension
+// and be on a single line.
+// Run will set stdout and stderr to inherit and not
+// capture the output.
+//
+// Example:
+//
+//	dash.Run("/path/to/script.sh")
+func Run(script string) (*exec.PsOutput, error) {
+	return proc.Run(context.Background(), Script(script))
+}
+
+// Output a new dash inline script or file.
+// When using a file, the file must have a .sh extension
+// and be on a single line.
+// Output will set stdout and stderr to piped and captures
+// the standard output and error streams
+//
+// Example:
+//
+//	out, err := dash.Output("/path/to/script.sh")
+//	if err != nil || out.Code != 0 {
+//	// handle error
+//	}
+func Output(script string) (*exec.PsOutput, error) {
+	return proc.Output(context.Background(), Script(script))
+}
@@ -0,0 +1,29 @@
+package dash
+
+import (
+	"github.com/jolt9dev/go-spawn/internal/probe"
+	"github.com/jolt9dev/go-spawn/shells"
+)
+
+// Returns the features of the resolved dash. dash supports -e and
+// -u, pipefail only since 0.5.12, which is probed once per
+// executable, and has no process substitution or arrays. The zero
+// value is returned when dash is not installed.
+//
+// Example:
+//
+//	if dash.Features().PipeFail {
+//		dash.New("-e", "-o", "pipefail", "-c", "curl -fsSL $URL | tar -xz").Run()
+//	}
+func Features() shells.ShellFeatures {
+	exe := Which()
+	if exe == "" {
+		return shells.ShellFeatures{}
+	}
+
+	return shells.ShellFeatures{
+		ErrExit:  true,
+		PipeFail: probe.Succeeds(exe, "-c", "set -o pipefail"),
+		NoUnset:  true,
+	}
+}
@@ -0,0 +1,56 @@
+package shells
+
+// ShellFeatures reports the features of the installed version of a
+// shell, so that callers can avoid flags and syntax it does not
+// support, e.g. pipefail in busybox ash or ksh88.
+type ShellFeatures struct {
+	// Supports exiting on the first failing command with set -e.
+	ErrExit bool
+
+	// Supports failing a pipeline when any command in it fails
+	// with set -o pipefail.
+	PipeFail bool
+
+	// Supports treating unset variables as an error with set -u.
+	NoUnset bool
+
+	// Supports process substitution such as <(command).
+	ProcessSubstitution bool
+
+	// Supports array variables.
+	Arrays bool
+}
+
+// FeatureShell is implemented by the shells that report the
+// features of their installed version, which is every shell
+// package of this module.
+type FeatureShell interface {
+	Shell
+
+	// Returns the features of the installed version of the shell
+	Features() ShellFeatures
+}
+
+// Returns the features of the shell registered with the given name.
+// False is returned when the shell is not registered or does not
+// report its features.
+//
+// Example:
+//
+//	f, ok := shells.Features("sh")
+//	if ok && !f.PipeFail {
+//		log.Println("pipelines only fail when the last command fails")
+//	}
+func Features(name string) (ShellFeatures, bool) {
+	shell, ok := Get(name)
+	if !ok {
+		return ShellFeatures{}, false
+	}
+
+	fs, ok := shell.(FeatureShell)
+	if !ok {
+		return ShellFeatures{}, false
+	}
+
+	return fs.Features(), true
+}
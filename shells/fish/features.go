@@ -0,0 +1,21 @@
+package fish
+
+import "github.com/jolt9dev/go-spawn/shells"
+
+// Returns the features of the resolved fish. fish has lists but no
+// set -e, pipefail, set -u or <(command) process substitution. Use
+// psub for the latter. The zero value is returned when fish is not
+// installed.
+//
+// Example:
+//
+//	if !fish.Features().ErrExit {
+//		fish.Run("make build; or exit 1")
+//	}
+func Features() shells.ShellFeatures {
+	if Which() == "" {
+		return shells.ShellFeatures{}
+	}
+
+	return shells.ShellFeatures{Arrays: true}
+}
@@ -0,0 +1,166 @@
+// Package fish runs fish shell inline scripts and files.
+//
+// Fish does not support set -e or pipefail, so a script does not
+// stop when a command fails. The exit code of a script is the
+// status of the last command that ran, so scripts must check
+// $status or use `or exit` to fail early:
+//
+//	fish.Run(`make build; or exit 1
+//	make test`)
+package fish
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/lookup"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+func init() {
+	exec.Register("fish", &exec.Executable{
+		Name:     "fish",
+		Variable: "FISH_PATH",
+		Linux: []string{
+			"/usr/bin/fish",
+			"/bin/fish",
+			"/usr/local/bin/fish",
+		},
+		Darwin: []string{
+			"/opt/homebrew/bin/fish",
+			"/usr/local/bin/fish",
+		},
+	})
+}
+
+// Returns the path to the fish executable or an empty string.
+// The path is resolved in order from FISH_PATH, which may be quoted
+// and contain variables such as ${HOME}, then the known locations
+// and then the PATH.
+func Which() string {
+	if exe := WhichFromEnv(); exe != "" {
+		return exe
+	}
+
+	exe, _ := exec.Find("fish")
+	return exe
+}
+
+// Returns the unquoted and expanded path from FISH_PATH when it
+// is an existing file, otherwise an empty string.
+//
+// Example:
+//
+//	if fish.WhichFromEnv() == "" {
+//		log.Println("FISH_PATH is not set, probing for fish")
+//	}
+func WhichFromEnv() string {
+	return lookup.FromEnv("FISH_PATH")
+}
+
+// Returns the path to the fish executable or the default
+// which is the name of the executable without a path or
+// extension when it is not found.
+func WhichOrDefault() string {
+	exe := Which()
+	if exe == "" {
+		return "fish"
+	}
+
+	return exe
+}
+
+// Returns the locations probed for fish on the current os
+// in order with environment variables expanded, starting with
+// FISH_PATH when set. PATH is searched when none of them exist.
+//
+// Example:
+//
+//	fmt.Println("searched:", strings.Join(fish.Candidates(), ", "))
+func Candidates() []string {
+	return lookup.Candidates("fish")
+}
+
+// Creates a new fish command with the given arguments
+// using vardiac arguments
+//
+// Example:
+//
+//	fish.New("--no-config", "-c", "echo hello").Run()
+func New(args ...string) *exec.Cmd {
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Creates a new fish command with the given arguments
+// using a single string
+//
+// Example:
+//
+//	fish.Command("--no-config -c 'echo hello'").Run()
+func Command(args string) *exec.Cmd {
+	return exec.New(WhichOrDefault(), exec.SplitArgs(args)...)
+}
+
+// Creates a new fish command with the given script file
+//
+// Example:
+//
+//	fish.File("script.fish").Run()
+func File(file string) *exec.Cmd {
+	args := []string{"--no-config", file}
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Creates a new fish command with the given inline script
+// or file. However, the file must have a .fish extension
+// and be on a single line. Unlike bash, the script does
+// not stop when a command fails.
+//
+// Example:
+//
+//	fish.Script(`for f in *.txt
+//	  echo $f
+//	end`).WithCwd("/path/to/dir").Run()
+//	fish.Script("/path/to/script.fish").Output()
+func Script(script string) *exec.Cmd {
+	if !strings.ContainsAny(script, "\n") {
+		script = strings.TrimSpace(script)
+
+		if strings.HasSuffix(script, ".fish") {
+			return File(script)
+		}
+	}
+
+	args := []string{"--no-config", "-c", script}
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Run a new fish inline script or file.
+// When using a file, the file must have a .fish extension
+// and be on a single line.
+// Run will set stdout and stderr to inherit and not
+// capture the output.
+//
+// Example:
+//
+//	fish.Run("/path/to/script.fish")
+func Run(script string) (*exec.PsOutput, error) {
+	return proc.Run(context.Background(), Script(script))
+}
+
+// Output a new fish inline script or file.
+// When using a file, the file must have a .fish extension
+// and be on a single line.
+// Output will set stdout and stderr to piped and captures
+// the standard output and error streams
+//
+// Example:
+//
+//	out, err := fish.Output("/path/to/script.fish")
+//	if err != nil || out.Code != 0 {
+//	// handle error
+//	}
+func Output(script string) (*exec.PsOutput, error) {
+	return proc.Output(context.Background(), Script(script))
+}
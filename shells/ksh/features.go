@@ -0,0 +1,31 @@
+package ksh
+
+import (
+	"github.com/jolt9dev/go-spawn/internal/probe"
+	"github.com/jolt9dev/go-spawn/shells"
+)
+
+// Returns the features of the resolved ksh. ksh93 supports each of
+// them while ksh88 supports neither pipefail nor process
+// substitution on every platform, so both are probed once per
+// executable. The zero value is returned when ksh is not installed.
+//
+// Example:
+//
+//	if !ksh.Features().PipeFail {
+//		log.Println("ksh88: pipelines only fail when the last command fails")
+//	}
+func Features() shells.ShellFeatures {
+	exe := Which()
+	if exe == "" {
+		return shells.ShellFeatures{}
+	}
+
+	return shells.ShellFeatures{
+		ErrExit:             true,
+		PipeFail:            probe.Succeeds(exe, "-c", "set -o pipefail"),
+		NoUnset:             true,
+		ProcessSubstitution: probe.Succeeds(exe, "-c", "cat <(:)"),
+		Arrays:              true,
+	}
+}
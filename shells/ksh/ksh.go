@@ -0,0 +1,175 @@
+package ksh
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/lookup"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+func init() {
+	exec.Register("ksh", &exec.Executable{
+		Name:     "ksh",
+		Variable: "KSH_PATH",
+		Linux: []string{
+			"/bin/ksh",
+			"/usr/bin/ksh",
+			"/bin/ksh93",
+			"/usr/bin/ksh93",
+		},
+	})
+}
+
+// Returns the path to the ksh executable or an empty string.
+// The path is resolved in order from KSH_PATH, which may be quoted
+// and contain variables such as ${HOME}, then the known locations
+// and then the PATH.
+func Which() string {
+	if exe := WhichFromEnv(); exe != "" {
+		return exe
+	}
+
+	exe, _ := exec.Find("ksh")
+	return exe
+}
+
+// Returns the unquoted and expanded path from KSH_PATH when it
+// is an existing file, otherwise an empty string.
+//
+// Example:
+//
+//	if ksh.WhichFromEnv() == "" {
+//		log.Println("KSH_PATH is not set, probing for ksh")
+//	}
+func WhichFromEnv() string {
+	return lookup.FromEnv("KSH_PATH")
+}
+
+// Returns the path to the ksh executable or the default
+// which is the name of the executable without a path or
+// extension when it is not found.
+func WhichOrDefault() string {
+	exe := Which()
+	if exe == "" {
+		return "ksh"
+	}
+
+	return exe
+}
+
+// Reports whether the resolved ksh supports set -o pipefail.
+// ksh93 supports it while older ksh88 releases do not. The
+// result is probed once per executable and cached.
+func SupportsPipeFail() bool {
+	return Features().PipeFail
+}
+
+// Returns the locations probed for ksh on the current os
+// in order with environment variables expanded, starting with
+// KSH_PATH when set. PATH is searched when none of them exist.
+//
+// Example:
+//
+//	fmt.Println("searched:", strings.Join(ksh.Candidates(), ", "))
+func Candidates() []string {
+	return lookup.Candidates("ksh")
+}
+
+// Creates a new ksh command with the given arguments
+// using vardiac arguments
+//
+// Example:
+//
+//	ksh.New("-e", "-o", "pipefail", "-c", "echo hello").Run()
+func New(args ...string) *exec.Cmd {
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Creates a new ksh command with the given arguments
+// using a single string
+//
+// Example:
+//
+//	ksh.Command("-e -o pipefail -c 'echo hello'").Run()
+func Command(args string) *exec.Cmd {
+	return exec.New(WhichOrDefault(), exec.SplitArgs(args)...)
+}
+
+// Creates a new ksh command with the given script file
+//
+// Example:
+//
+//	ksh.File("script.ksh").Run()
+func File(file string) *exec.Cmd {
+	args := append(flags(), file)
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Creates a new ksh command with the given inline script
+// or file. However, the file must have a .ksh or .sh extension
+// and be on a single line. pipefail is only set when the
+// resolved ksh supports it.
+//
+// Example:
+//
+//	ksh.Script(`ls -l | \
+//	  grep foo`).WithCwd("/path/to/dir").Run()
+//	ksh.Script("/path/to/script.ksh").Output()
+func Script(script string) *exec.Cmd {
+	if !strings.ContainsAny(script, "\n") {
+		script = strings.TrimSpace(script)
+
+		if strings.HasSuffix(script, ".ksh") || strings.HasSuffix(script, ".sh") {
+			return File(script)
+		}
+	}
+
+	args := append(flags(), "-c", script)
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Run a new ksh inline script or file.
+// When using a file, the file must have a .ksh or .sh extension
+// and be on a single line.
+// Run will set stdout and stderr to inherit and not
+// capture the output.
+//
+// Example:
+//
+//	ksh.Run("/path/to/script.ksh")
+func Run(script string) (*exec.PsOutput, error) {
+	return proc.Run(context.Background(), Script(script))
+}
+
+// Output a new ksh inline script or file.
+// When using a file, the file must have a .ksh or .sh extension
+// and be on a single line.
+// Output will set stdout and stderr to piped and captures
+// the standard output and error streams
+//
+// Example:
+//
+//	out, err := ksh.Output("/path/to/script.ksh")
+//	if err != nil || out.Code != 0 {
+//	// handle error
+//	}
+func Output(script string) (*exec.PsOutput, error) {
+	return proc.Output(context.Background(), Script(script))
+}
+
+// ksh88 does not support pipefail so it is only set when supported
+func flags() []string {
+	f := Features()
+	flags := []string{}
+	if f.ErrExit {
+		flags = append(flags, "-e")
+	}
+
+	if f.PipeFail {
+		flags = append(flags, "-o", "pipefail")
+	}
+
+	return flags
+}
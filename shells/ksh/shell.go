@@ -0,0 +1,23 @@
+package ksh
+
+import (
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/shells"
+)
+
+// implements shells.Shell using the package functions
+type shell struct{}
+
+func init() {
+	shells.Register("ksh", shell{})
+}
+
+func (shell) Name() string                                 { return "ksh" }
+func (shell) Which() string                                { return Which() }
+func (shell) New(args ...string) *exec.Cmd                 { return New(args...) }
+func (shell) Command(args string) *exec.Cmd                { return Command(args) }
+func (shell) File(file string) *exec.Cmd                   { return File(file) }
+func (shell) Script(script string) *exec.Cmd               { return Script(script) }
+func (shell) Run(script string) (*exec.PsOutput, error)    { return Run(script) }
+func (shell) Output(script string) (*exec.PsOutput, error) { return Output(script) }
+func (shell) Features() shells.ShellFeatures               { return Features() }
@@ -0,0 +1,62 @@
+package powershell
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jolt9dev/go-platform"
+	"github.com/jolt9dev/go-spawn/internal/psscript"
+)
+
+// ErrApartmentState is returned when running a command created with
+// an apartment state other than STA or MTA.
+var ErrApartmentState = errors.New("powershell: the apartment state must be STA or MTA")
+
+// Creates new options from the package level defaults that start
+// powershell in the given COM apartment, either STA or MTA, which is
+// passed with -Sta or -Mta. Scripts that use WinForms, WPF or some
+// COM objects require STA. The flag is skipped when the resolved
+// powershell does not support it: on platforms other than Windows,
+// where pwsh fails on the flags, with pwsh older than 7 and with
+// -Mta for Windows PowerShell older than 3.0. Checking the version
+// runs powershell once per executable, see Version.
+//
+// Example:
+//
+//	powershell.WithApartmentState("STA").Run(`Add-Type -AssemblyName System.Windows.Forms
+//	[System.Windows.Forms.Clipboard]::SetText("hello")`)
+func WithApartmentState(state string) *Options {
+	return NewOptions().WithApartmentState(state)
+}
+
+// Sets the COM apartment powershell starts in, either STA or MTA
+func (o *Options) WithApartmentState(state string) *Options {
+	o.ApartmentState = state
+	return o
+}
+
+// returns the flag for the apartment state or an empty string when
+// it is not set or not supported by the resolved powershell
+func apartmentFlag(state string) string {
+	flag := psscript.ApartmentFlag(state)
+	if flag == "" || !platform.IsWindows() {
+		return ""
+	}
+
+	// let powershell report the flag when the version is unknown
+	if v, err := Version(); err == nil {
+		major, _ := strconv.Atoi(strings.SplitN(v, ".", 2)[0])
+		name := strings.TrimSuffix(strings.ToLower(filepath.Base(Which())), ".exe")
+		if name == "pwsh" && major < 7 {
+			return ""
+		}
+
+		if name != "pwsh" && flag == "-Mta" && major < 3 {
+			return ""
+		}
+	}
+
+	return flag
+}
@@ -0,0 +1,24 @@
+package powershell
+
+// Returns the resolved executable and the full argument vector
+// that Script would run for the inline script or file without
+// running anything, which is useful for logging or testing how
+// a command is constructed. The arguments reflect all options
+// such as -EncodedCommand and -ExecutionPolicy. Scripts with
+// here-strings are reported with -EncodedCommand, while Run and
+// Output pass them in a temp file.
+//
+// Example:
+//
+//	exe, args := powershell.ScriptArgs("Write-Host hello")
+//	log.Println(exe, args)
+func ScriptArgs(script string) (path string, args []string) {
+	return NewOptions().ScriptArgs(script)
+}
+
+// Returns the resolved executable and the full argument vector
+// for the inline script or file using the options
+func (o *Options) ScriptArgs(script string) (path string, args []string) {
+	cmd := o.Script(script)
+	return cmd.Path, append([]string{}, cmd.Args[1:]...)
+}
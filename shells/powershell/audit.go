@@ -0,0 +1,42 @@
+package powershell
+
+import (
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Called with the executable, arguments and working directory of
+// each command right before Run, Output and the other functions of
+// the package that run a command start the process, after BeforeRun.
+// The command line is logged as executed, after the flags, encoding,
+// WSL translation and any changes made by BeforeRun, so it matches
+// what the os runs. The arguments contain inline scripts verbatim,
+// so callers are responsible for redacting secrets. Like BeforeRun,
+// it is not called for commands run directly with the methods of
+// exec.Cmd or when a test runner is set. Set it once at startup.
+//
+// Example:
+//
+//	powershell.AuditLog = func(exe string, args []string, cwd string) {
+//		log.Printf("audit: %s %q in %s", exe, args, cwd)
+//	}
+var AuditLog func(exe string, args []string, cwd string)
+
+// returns the func called right before a command starts which
+// calls BeforeRun and AuditLog when set
+func beforeRun() func(cmd *exec.Cmd) {
+	before, audit := BeforeRun, AuditLog
+	if before == nil && audit == nil {
+		return nil
+	}
+
+	return func(cmd *exec.Cmd) {
+		if before != nil {
+			before(cmd)
+		}
+
+		if audit != nil {
+			audit(cmd.Path, append([]string{}, cmd.Args[1:]...), proc.Cwd(cmd))
+		}
+	}
+}
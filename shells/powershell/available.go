@@ -0,0 +1,31 @@
+package powershell
+
+import (
+	"github.com/jolt9dev/go-exec"
+)
+
+// Runs a new powershell inline script or file with stdout and stderr
+// inherited from the current process when powershell is installed. When
+// Which does not find powershell, the script is not run and ran is false
+// without an error, so optional steps can be skipped gracefully.
+//
+// Example:
+//
+//	ran, _, err := powershell.RunIfAvailable("Write-Output hello")
+//	if err == nil && !ran {
+//		log.Println("powershell is not installed, skipping")
+//	}
+func RunIfAvailable(script string) (ran bool, out *exec.PsOutput, err error) {
+	return NewOptions().RunIfAvailable(script)
+}
+
+// Runs the inline script or file when powershell is installed and
+// reports whether it ran
+func (o *Options) RunIfAvailable(script string) (ran bool, out *exec.PsOutput, err error) {
+	if Which() == "" {
+		return false, nil, nil
+	}
+
+	out, err = o.Run(script)
+	return true, out, err
+}
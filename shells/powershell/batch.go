@@ -0,0 +1,58 @@
+package powershell
+
+import (
+	"github.com/jolt9dev/go-exec"
+)
+
+// Outputs each inline script in a single powershell process and
+// returns the output of each script in order, which avoids starting
+// a process per script. Starting powershell takes a considerable
+// fraction of a second, so batches run much faster than calling
+// Output for each script. Each script runs in its own child scope
+// so that variables and functions do not leak into the scripts after
+// it, however imported modules and the location are shared. When
+// StopOnError is set, the preference only applies to the scope of
+// the script. A failing script is reported by the Code of its
+// output. Calling exit ends the process and fails the batch. An
+// error is only returned when powershell cannot be started or exits
+// unexpectedly, along with the outputs of the scripts that
+// completed.
+//
+// Example:
+//
+//	outs, err := powershell.Batch([]string{"Get-Date", "$PSVersionTable.PSVersion"})
+//	if err != nil {
+//		return err
+//	}
+//
+//	for _, out := range outs {
+//		fmt.Println(out.Code, out.Text())
+//	}
+func Batch(scripts []string) ([]*exec.PsOutput, error) {
+	return NewOptions().Batch(scripts)
+}
+
+// Outputs each inline script in a single powershell process
+func (o *Options) Batch(scripts []string) ([]*exec.PsOutput, error) {
+	outs := make([]*exec.PsOutput, 0, len(scripts))
+	s, err := o.NewSession()
+	if err != nil {
+		return outs, err
+	}
+
+	defer s.Close()
+	for _, script := range scripts {
+		if o.StopOnError {
+			script = "$ErrorActionPreference = 'Stop'\n" + script
+		}
+
+		out, err := s.SendOutput("& {\n" + script + "\n}")
+		if err != nil {
+			return outs, err
+		}
+
+		outs = append(outs, out)
+	}
+
+	return outs, nil
+}
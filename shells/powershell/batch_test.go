@@ -0,0 +1,45 @@
+package powershell
+
+import (
+	"strconv"
+	"testing"
+)
+
+func benchmarkScripts(n int) []string {
+	scripts := make([]string, n)
+	for i := range scripts {
+		scripts[i] = "Write-Output " + strconv.Itoa(i)
+	}
+
+	return scripts
+}
+
+func BenchmarkBatch(b *testing.B) {
+	if Which() == "" {
+		b.Skip("powershell not found")
+	}
+
+	scripts := benchmarkScripts(10)
+	b.ResetTimer()
+	for range b.N {
+		if _, err := Batch(scripts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkOutput(b *testing.B) {
+	if Which() == "" {
+		b.Skip("powershell not found")
+	}
+
+	scripts := benchmarkScripts(10)
+	b.ResetTimer()
+	for range b.N {
+		for _, script := range scripts {
+			if _, err := Output(script); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package powershell
+
+import (
+	"maps"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Builder configures a powershell command with chained calls and
+// creates it with Build or runs it with Run or Output. Every method
+// returns a modified copy, so a base builder can be shared and
+// specialized without affecting it. The command is the same as the
+// one created by Options for the same settings.
+//
+// Example:
+//
+//	base := powershell.NewBuilder().Cwd("C:\\app").Env(map[string]string{"CI": "true"})
+//	base.Script("./build.ps1").Run()
+//	base.Script("Invoke-Pester").StopOnError(false).Timeout(10 * time.Minute).Output()
+type Builder struct {
+	options Options
+	script  string
+}
+
+// Creates a new builder initialized from the package level
+// defaults the same as NewOptions.
+func NewBuilder() Builder {
+	return Builder{options: *NewOptions()}
+}
+
+// Sets the inline script or file to run
+func (b Builder) Script(script string) Builder {
+	b = b.clone()
+	b.script = script
+	return b
+}
+
+// Sets the working directory of the command
+func (b Builder) Cwd(dir string) Builder {
+	b = b.clone()
+	b.options.Dir = dir
+	return b
+}
+
+// Sets environment variables that are merged onto the current
+// process environment, or replace it when ClearEnv is set
+func (b Builder) Env(env map[string]string) Builder {
+	b = b.clone()
+	b.options.WithEnv(env)
+	return b
+}
+
+// Sets whether the current process environment is excluded
+// from the command environment
+func (b Builder) ClearEnv(clear bool) Builder {
+	b = b.clone()
+	b.options.ClearEnv = clear
+	return b
+}
+
+// Sets whether inline scripts stop on the first cmdlet error
+func (b Builder) StopOnError(stop bool) Builder {
+	b = b.clone()
+	b.options.StopOnError = stop
+	return b
+}
+
+// Sets whether inline scripts fail when any error was recorded
+func (b Builder) ErrorAsFailure(fail bool) Builder {
+	b = b.clone()
+	b.options.ErrorAsFailure = fail
+	return b
+}
+
+// Sets the execution policy passed with -ExecutionPolicy on Windows
+func (b Builder) ExecutionPolicy(policy string) Builder {
+	b = b.clone()
+	b.options.ExecutionPolicy = policy
+	return b
+}
+
+// Sets the maximum duration of Run and Output. Commands created
+// with Build are not limited.
+func (b Builder) Timeout(timeout time.Duration) Builder {
+	b = b.clone()
+	b.options.Timeout = timeout
+	return b
+}
+
+// Returns a copy of the options of the builder for the settings
+// that have no builder method
+func (b Builder) Options() *Options {
+	o := b.clone().options
+	return &o
+}
+
+// Creates the powershell command for the script
+func (b Builder) Build() *exec.Cmd {
+	return b.options.Script(b.script)
+}
+
+// Runs the script with stdout and stderr inherited from the
+// current process the same as Options.Run
+func (b Builder) Run() (*exec.PsOutput, error) {
+	return b.Options().Run(b.script)
+}
+
+// Runs the script and captures stdout and stderr the same as
+// Options.Output
+func (b Builder) Output() (*exec.PsOutput, error) {
+	return b.Options().Output(b.script)
+}
+
+// returns a copy that does not share the env with the builder
+func (b Builder) clone() Builder {
+	b.options.Env = maps.Clone(b.options.Env)
+	return b
+}
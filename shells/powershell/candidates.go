@@ -0,0 +1,26 @@
+package powershell
+
+import (
+	"github.com/jolt9dev/go-platform"
+	"github.com/jolt9dev/go-spawn/internal/lookup"
+)
+
+// Returns the locations probed for pwsh and, on windows,
+// powershell.exe on the current os in order with environment
+// variables such as ${ProgramFiles} expanded, starting with
+// PWSH_PATH and POWERSHELL_PATH when set. PATH is searched when
+// none of them exist. These are the locations listed by
+// ErrShellNotFound.
+//
+// Example:
+//
+//	if powershell.Which() == "" {
+//		fmt.Println("powershell not found, searched:", strings.Join(powershell.Candidates(), ", "))
+//	}
+func Candidates() []string {
+	if platform.IsWindows() {
+		return lookup.Candidates("pwsh", "powershell")
+	}
+
+	return lookup.Candidates("pwsh")
+}
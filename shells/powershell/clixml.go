@@ -0,0 +1,286 @@
+package powershell
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/psscript"
+)
+
+var cliXmlHeader = []byte("#< CLIXML")
+
+// Outputs a new powershell inline script or file with
+// -OutputFormat Xml so that stdout contains the result objects
+// serialized as CLIXML. Use ParseCliXml to deserialize stdout.
+//
+// Example:
+//
+//	out, err := powershell.OutputCliXml("Get-Service | Select-Object Name, Status")
+//	objs, err := powershell.ParseCliXml(out.Stdout)
+func OutputCliXml(script string) (*exec.PsOutput, error) {
+	return NewOptions().OutputCliXml(script)
+}
+
+// Outputs the inline script or file with -OutputFormat Xml
+func (o *Options) OutputCliXml(script string) (*exec.PsOutput, error) {
+	opts := *o
+	opts.OutputFormat = "Xml"
+	return opts.Output(script)
+}
+
+// Parses the CLIXML written by OutputCliXml into the objects of
+// the output stream. Objects with properties are returned as
+// map[string]any, collections as []any, dictionaries as
+// map[string]any keyed by the string form of the key and
+// primitives as their closest go type. Error and other stream
+// records are skipped.
+//
+// CLIXML is a lossy format: objects are deserialized as property
+// bags without methods, and properties nested deeper than the
+// serialization depth of the host (1 by default) are only
+// available as their ToString value.
+//
+// Example:
+//
+//	objs, err := powershell.ParseCliXml(out.Stdout)
+//	for _, obj := range objs {
+//		svc := obj.(map[string]any)
+//		fmt.Println(svc["Name"], svc["Status"])
+//	}
+func ParseCliXml(data []byte) ([]any, error) {
+	data = psscript.TrimBom(data)
+	for {
+		data = bytes.TrimSpace(data)
+		if !bytes.HasPrefix(data, cliXmlHeader) {
+			break
+		}
+
+		// the header precedes every document written to the stream
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			data = nil
+			break
+		}
+
+		data = data[i+1:]
+	}
+
+	values := []any{}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	refs := map[string]any{}
+	for {
+		var root cliXmlNode
+		err := dec.Decode(&root)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return values, nil
+			}
+
+			return values, fmt.Errorf("powershell: invalid clixml: %w", err)
+		}
+
+		if root.XMLName.Local != "Objs" {
+			return values, fmt.Errorf("powershell: invalid clixml: unexpected element %s", root.XMLName.Local)
+		}
+
+		for _, node := range root.Nodes {
+			// records written to the error, verbose and other streams
+			if node.attr("S") != "" {
+				continue
+			}
+
+			values = append(values, node.value(refs))
+		}
+	}
+}
+
+type cliXmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr   `xml:",any,attr"`
+	Content string       `xml:",chardata"`
+	Nodes   []cliXmlNode `xml:",any"`
+}
+
+func (n *cliXmlNode) attr(name string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+func (n *cliXmlNode) child(name string) *cliXmlNode {
+	for i := range n.Nodes {
+		if n.Nodes[i].XMLName.Local == name {
+			return &n.Nodes[i]
+		}
+	}
+
+	return nil
+}
+
+// converts the node to a go value, objects are recorded in refs
+// by their RefId so that later <Ref> nodes resolve to them
+func (n *cliXmlNode) value(refs map[string]any) any {
+	switch n.XMLName.Local {
+	case "Obj":
+		v := n.object(refs)
+		if id := n.attr("RefId"); id != "" {
+			refs[id] = v
+		}
+
+		return v
+	case "Ref":
+		return refs[n.attr("RefId")]
+	case "Nil":
+		return nil
+	case "S", "TS", "G", "URI", "Version", "XD", "SBK", "SS", "ToString":
+		return decodeCliXmlString(n.Content)
+	case "C":
+		c, err := strconv.ParseUint(n.Content, 10, 16)
+		if err != nil {
+			return n.Content
+		}
+
+		return string(rune(c))
+	case "B":
+		return n.Content == "true"
+	case "SB", "I16", "I32", "I64":
+		v, err := strconv.ParseInt(n.Content, 10, 64)
+		if err != nil {
+			return n.Content
+		}
+
+		return v
+	case "By", "U16", "U32", "U64":
+		v, err := strconv.ParseUint(n.Content, 10, 64)
+		if err != nil {
+			return n.Content
+		}
+
+		return v
+	case "Sg", "Db", "D":
+		v, err := strconv.ParseFloat(n.Content, 64)
+		if err != nil {
+			return n.Content
+		}
+
+		return v
+	case "DT":
+		v, err := time.Parse(time.RFC3339Nano, n.Content)
+		if err != nil {
+			return n.Content
+		}
+
+		return v
+	case "BA":
+		v, err := base64.StdEncoding.DecodeString(n.Content)
+		if err != nil {
+			return n.Content
+		}
+
+		return v
+	}
+
+	return decodeCliXmlString(n.Content)
+}
+
+func (n *cliXmlNode) object(refs map[string]any) any {
+	props := map[string]any{}
+	isMap := false
+	for _, name := range []string{"MS", "Props"} {
+		if c := n.child(name); c != nil {
+			isMap = true
+			for i := range c.Nodes {
+				props[decodeCliXmlString(c.Nodes[i].attr("N"))] = c.Nodes[i].value(refs)
+			}
+		}
+	}
+
+	for _, name := range []string{"LST", "IE", "STK", "QUE"} {
+		if c := n.child(name); c != nil {
+			list := make([]any, 0, len(c.Nodes))
+			for i := range c.Nodes {
+				list = append(list, c.Nodes[i].value(refs))
+			}
+
+			if !isMap {
+				return list
+			}
+
+			props[name] = list
+		}
+	}
+
+	if c := n.child("DCT"); c != nil {
+		for _, en := range c.Nodes {
+			var key string
+			var value any
+			for i := range en.Nodes {
+				switch en.Nodes[i].attr("N") {
+				case "Key":
+					key = fmt.Sprint(en.Nodes[i].value(refs))
+				case "Value":
+					value = en.Nodes[i].value(refs)
+				}
+			}
+
+			props[key] = value
+		}
+
+		return props
+	}
+
+	if isMap {
+		return props
+	}
+
+	// enums and other primitives wrapped with type information
+	for i := range n.Nodes {
+		switch n.Nodes[i].XMLName.Local {
+		case "TN", "TNRef", "ToString":
+			continue
+		}
+
+		return n.Nodes[i].value(refs)
+	}
+
+	if c := n.child("ToString"); c != nil {
+		return c.value(refs)
+	}
+
+	return nil
+}
+
+// decodes the _xHHHH_ escapes used for characters that are not
+// valid in xml
+func decodeCliXmlString(s string) string {
+	if !strings.Contains(s, "_x") {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '_' && i+7 <= len(s) && s[i+1] == 'x' && s[i+6] == '_' {
+			if c, err := strconv.ParseUint(s[i+2:i+6], 16, 16); err == nil {
+				sb.WriteRune(rune(c))
+				i += 6
+				continue
+			}
+		}
+
+		sb.WriteByte(s[i])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,64 @@
+package powershell
+
+import (
+	"context"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Runs a new powershell inline script or file under the given context
+// with stdout and stderr inherited from the current process.
+// The process and its children are killed when the context is
+// cancelled or its deadline is exceeded. On Windows, the process
+// is started in a job object, which the processes it starts join,
+// and the job is terminated. When stdin is a terminal, the
+// process stays in the foreground process group of the terminal so
+// that it can prompt for input, and its children are looked up with
+// ps when it is killed.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	powershell.RunContext(ctx, "Update-Help")
+func RunContext(ctx context.Context, script string) (*exec.PsOutput, error) {
+	return NewOptions().RunContext(ctx, script)
+}
+
+// Outputs a new powershell inline script or file under the given context
+// and captures stdout and stderr. When the context is done, the
+// output captured so far is returned with the context error.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	out, err := powershell.OutputContext(ctx, "Invoke-RestMethod https://example.com")
+//	if errors.Is(err, context.DeadlineExceeded) {
+//	// handle timeout
+//	}
+func OutputContext(ctx context.Context, script string) (*exec.PsOutput, error) {
+	return NewOptions().OutputContext(ctx, script)
+}
+
+// Runs the inline script or file under the given context with
+// stdout and stderr inherited from the current process unless the
+// run mode of the options is set otherwise
+func (o *Options) RunContext(ctx context.Context, script string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
+	out, err := o.run(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
+
+// Runs the inline script or file under the given context and
+// captures stdout and stderr
+func (o *Options) OutputContext(ctx context.Context, script string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	ctx, cancel := o.context(ctx)
+	defer cancel()
+	out, err := proc.Output(ctx, cmd)
+	return o.normalize(out), o.finish(cmd, err)
+}
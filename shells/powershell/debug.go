@@ -0,0 +1,24 @@
+package powershell
+
+import "github.com/jolt9dev/go-exec"
+
+// Creates a new powershell command with the given inline script
+// that traces each line with Set-PSDebug -Trace 1 before it runs.
+// The trace is written by the host as DEBUG: lines, which is
+// stdout rather than stderr for pwsh and powershell.exe, so
+// enabling it changes the output captured by Output and breaks
+// helpers that parse stdout such as OutputJSON. Files run with
+// -File are not traced.
+//
+// Example:
+//
+//	powershell.ScriptDebug("Get-ChildItem | Select-Object Name").Run()
+func ScriptDebug(script string) *exec.Cmd {
+	return NewOptions().WithDebug(true).Script(script)
+}
+
+// Sets whether inline scripts are traced with Set-PSDebug
+func (o *Options) WithDebug(debug bool) *Options {
+	o.Debug = debug
+	return o
+}
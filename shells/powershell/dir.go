@@ -0,0 +1,46 @@
+package powershell
+
+import (
+	"github.com/jolt9dev/go-exec"
+)
+
+// Creates a new powershell command with the given inline script or
+// file that runs in the given working directory. Inline scripts
+// start with Set-Location -LiteralPath so that $PWD, relative
+// cmdlet paths and native tools agree on the directory. When the
+// directory does not exist or is not a directory, the error is
+// returned when the command is run without spawning the process.
+//
+// Example:
+//
+//	powershell.ScriptIn("C:\\src\\app", "Get-ChildItem -Name").Run()
+func ScriptIn(dir, script string) *exec.Cmd {
+	return NewOptions().WithDir(dir).Script(script)
+}
+
+// Runs a new powershell inline script or file in the given working
+// directory with stdout and stderr inherited from the current
+// process. See ScriptIn.
+//
+// Example:
+//
+//	powershell.RunIn("C:\\src\\app", "dotnet build")
+func RunIn(dir, script string) (*exec.PsOutput, error) {
+	return NewOptions().WithDir(dir).Run(script)
+}
+
+// Outputs a new powershell inline script or file in the given
+// working directory and captures stdout and stderr. See ScriptIn.
+//
+// Example:
+//
+//	out, err := powershell.OutputIn("C:\\src\\app", "(Get-Location).Path")
+func OutputIn(dir, script string) (*exec.PsOutput, error) {
+	return NewOptions().WithDir(dir).Output(script)
+}
+
+// Sets the working directory of the command
+func (o *Options) WithDir(dir string) *Options {
+	o.Dir = dir
+	return o
+}
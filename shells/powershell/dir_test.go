@@ -0,0 +1,69 @@
+package powershell
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScriptInSetsLocation(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "it's a dir")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := ScriptIn(dir, "Get-Date")
+	if cmd.Dir != dir {
+		t.Errorf("cmd.Dir = %q, want %q", cmd.Dir, dir)
+	}
+
+	script := cmd.Args[len(cmd.Args)-1]
+	if !strings.Contains(script, "Set-Location -LiteralPath "+Quote(dir)+"\n") {
+		t.Errorf("script = %q, want it to set the location to %q", script, dir)
+	}
+}
+
+func TestScriptInMissingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	if cmd := ScriptIn(dir, "Get-Date"); cmd.Err == nil {
+		t.Errorf("want an error for the missing directory %s", dir)
+	}
+}
+
+func TestOutputInLocation(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	dir := filepath.Join(t.TempDir(), "it's a dir")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := OutputIn(dir, "(Get-Location).Path\n$PWD.Path\n[Environment]::CurrentDirectory")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out.Stdout)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("stdout = %q, want 3 lines", out.Stdout)
+	}
+
+	for i, name := range []string{"Get-Location", "$PWD", "CurrentDirectory"} {
+		got, err := filepath.EvalSymlinks(strings.TrimSpace(lines[i]))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package powershell
+
+import (
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/psscript"
+)
+
+// When true, Script will pass inline scripts that contain
+// characters that are mangled by cmd or CreateProcess quoting
+// using -EncodedCommand instead of -Command.
+var UseEncodedCommand = false
+
+// Creates a new powershell command with the given inline script
+// encoded as UTF-16LE base64 and passed with -EncodedCommand
+// which avoids any quoting issues.
+//
+// Example:
+//
+//	powershell.ScriptEncoded("Write-Host \"a;b`n`c\"").Run()
+func ScriptEncoded(script string) *exec.Cmd {
+	return NewOptions().ScriptEncoded(script)
+}
+
+// Returns the script encoded as base64 UTF-16LE which is
+// the format expected by -EncodedCommand
+func EncodeCommand(script string) string {
+	return psscript.Encode(script)
+}
+
+// Returns the script decoded from the base64 UTF-16LE
+// format used by -EncodedCommand
+func DecodeCommand(encoded string) (string, error) {
+	return psscript.Decode(encoded)
+}
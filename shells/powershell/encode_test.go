@@ -0,0 +1,91 @@
+package powershell
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEncodeCommand(t *testing.T) {
+	tests := []struct {
+		script  string
+		encoded string
+	}{
+		{"", ""},
+		{"a", "YQA="},
+		{"Write-Host hi", "VwByAGkAdABlAC0ASABvAHMAdAAgAGgAaQA="},
+		{"hé", "aADpAA=="},
+		{"😀", "PdgA3g=="},
+		{"a\r\nb", "YQANAAoAYgA="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.script, func(t *testing.T) {
+			if got := EncodeCommand(tt.script); got != tt.encoded {
+				t.Errorf("EncodeCommand(%q) = %q, want %q", tt.script, got, tt.encoded)
+			}
+
+			got, err := DecodeCommand(tt.encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.script {
+				t.Errorf("DecodeCommand(%q) = %q, want %q", tt.encoded, got, tt.script)
+			}
+		})
+	}
+}
+
+func TestDecodeCommandInvalid(t *testing.T) {
+	if _, err := DecodeCommand("not base64!"); err == nil {
+		t.Error("want an error for invalid base64")
+	}
+}
+
+func TestScriptUsesEncodedCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		encoded bool
+		want    bool
+	}{
+		{"disabled", `Write-Host "a;b"`, false, false},
+		{"plain script", "Get-Date", true, false},
+		{"double quotes", `Write-Host "a"`, true, true},
+		{"semicolon", "Get-Date; Get-Date", true, true},
+		{"pipe", "Get-Date | Out-String", true, true},
+		{"percent", "Write-Host 100%", true, true},
+		{"backtick", "Write-Host a`tb", true, true},
+		{"newline", "Get-Date\nGet-Date", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := NewOptions().WithEncodedCommand(tt.encoded).WithStopOnError(false).Script(tt.script).Args
+			i := slices.Index(args, "-EncodedCommand")
+			if got := i >= 0; got != tt.want {
+				t.Fatalf("args %v use -EncodedCommand = %v, want %v", args, got, tt.want)
+			}
+
+			if !tt.want {
+				return
+			}
+
+			script, err := DecodeCommand(args[i+1])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if script != tt.script {
+				t.Errorf("encoded script = %q, want %q", script, tt.script)
+			}
+		})
+	}
+}
+
+func TestScriptEncoded(t *testing.T) {
+	args := NewOptions().WithStopOnError(false).ScriptEncoded("Get-Date").Args
+	if len(args) < 2 || args[len(args)-2] != "-EncodedCommand" || args[len(args)-1] != EncodeCommand("Get-Date") {
+		t.Errorf("args %v do not end with the encoded script", args)
+	}
+}
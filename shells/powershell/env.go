@@ -0,0 +1,70 @@
+package powershell
+
+import (
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Creates a new powershell command with the given inline script
+// or file and environment variables merged onto the current
+// process environment.
+//
+// Example:
+//
+//	powershell.ScriptWithEnv("Write-Host $env:NAME", map[string]string{"NAME": "world"}).Output()
+func ScriptWithEnv(script string, env map[string]string) *exec.Cmd {
+	return NewOptions().WithEnv(env).Script(script)
+}
+
+// Creates new options from the package level defaults with
+// the given environment variables.
+//
+// Example:
+//
+//	powershell.WithEnv(map[string]string{"NAME": "world"}).Run("Write-Host $env:NAME")
+func WithEnv(env map[string]string) *Options {
+	return NewOptions().WithEnv(env)
+}
+
+// Sets environment variables that are merged onto the current
+// process environment, or replace it when ClearEnv is set
+func (o *Options) WithEnv(env map[string]string) *Options {
+	if o.Env == nil {
+		o.Env = map[string]string{}
+	}
+
+	for k, v := range env {
+		o.Env[k] = v
+	}
+
+	return o
+}
+
+// Sets whether the current process environment is excluded
+// from the command environment
+func (o *Options) WithClearEnv(clear bool) *Options {
+	o.ClearEnv = clear
+	return o
+}
+
+// Creates new options from the package level defaults that run
+// powershell with a minimal environment made of the given variables of
+// the current process and PATH, which keeps host variables such as
+// tokens from leaking into the script and makes runs reproducible.
+// On Windows, SystemRoot is kept as well since processes fail to
+// load system libraries without it. When PATH is not set, a default
+// search path is used. Variables set with WithEnv are still added.
+//
+// Example:
+//
+//	powershell.WithCleanEnv("HOME", "USERPROFILE").Run("Get-ChildItem Env:")
+func WithCleanEnv(keep ...string) *Options {
+	return NewOptions().WithCleanEnv(keep...)
+}
+
+// Sets the command environment to the given variables of the
+// current process and PATH, excluding everything else
+func (o *Options) WithCleanEnv(keep ...string) *Options {
+	o.ClearEnv = true
+	return o.WithEnv(proc.CleanEnv(keep))
+}
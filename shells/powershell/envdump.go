@@ -0,0 +1,87 @@
+package powershell
+
+import (
+	"errors"
+	"fmt"
+	osexec "os/exec"
+	"strings"
+
+	"github.com/jolt9dev/go-spawn/internal/envdump"
+	"github.com/jolt9dev/go-spawn/internal/psscript"
+)
+
+// Outputs a new powershell inline script or file and returns the
+// environment variables the script added or changed with $env:, which
+// brings the result of a setup script such as the Visual Studio
+// developer shell into the Go process. Get-ChildItem Env: is dumped
+// before and after the script, so values that span lines are returned
+// intact, and variables the script removed are not reported. The
+// script runs in a script block with its output discarded, files are
+// dot sourced into it. An error is returned when the script exits
+// with a non-zero code or calls exit before the environment is
+// dumped.
+//
+// Example:
+//
+//	env, err := powershell.OutputEnv(`Import-Module "$env:VSINSTALLDIR\Common7\Tools\Microsoft.VisualStudio.DevShell.dll"
+//	Enter-VsDevShell -VsInstallPath $env:VSINSTALLDIR -SkipAutomaticLocation`)
+//	if err != nil {
+//		return err
+//	}
+//
+//	for k, v := range env {
+//		os.Setenv(k, v)
+//	}
+func OutputEnv(script string) (map[string]string, error) {
+	return NewOptions().OutputEnv(script)
+}
+
+// Outputs the inline script or file and returns the environment
+// variables the script added or changed
+func (o *Options) OutputEnv(script string) (map[string]string, error) {
+	marker, err := envdump.Marker()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := o.Output(envScript(script, o.Dir, marker))
+	if out == nil {
+		return nil, err
+	}
+
+	var exitErr *osexec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, err
+	}
+
+	if out.Code != 0 {
+		stderr := strings.TrimSpace(string(out.Stderr))
+		if stderr != "" {
+			stderr = ": " + stderr
+		}
+
+		return nil, fmt.Errorf("powershell: script exited with code %d%s", out.Code, stderr)
+	}
+
+	env, err := envdump.Diff(out.Stdout, marker)
+	if err != nil {
+		return nil, fmt.Errorf("powershell: %w", err)
+	}
+
+	return env, nil
+}
+
+// returns the script that dumps the environment before and after
+// running the script. A script file is dot sourced by its absolute
+// path resolved against dir, since a bare file name is not found in
+// the current directory.
+func envScript(script, dir, marker string) string {
+	if file, ok := psscript.File(script); ok {
+		script = ". " + Quote(psscript.AbsFile(dir, file))
+	}
+
+	dump := "[Console]::Out.Write(" + Quote(marker) + " + [char]0)\n" +
+		"foreach ($e in Get-ChildItem Env:) { [Console]::Out.Write($e.Name + '=' + $e.Value + [char]0) }\n"
+	return dump + "[Console]::Out.Write(" + Quote(marker) + " + [char]0)\n" +
+		"$null = & {\n" + script + "\n}\n" + dump
+}
@@ -0,0 +1,57 @@
+package powershell
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnvScriptFile(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		script string
+		dir    string
+		want   string
+	}{
+		{"bare file", "setup.ps1", "", ". " + Quote(filepath.Join(cwd, "setup.ps1"))},
+		{"relative file", "./tools/setup.ps1", "", ". " + Quote(filepath.Join(cwd, "tools", "setup.ps1"))},
+		{"file in dir", "setup.ps1", dir, ". " + Quote(filepath.Join(dir, "setup.ps1"))},
+		{"inline", "$env:A = '1'", dir, "$env:A = '1'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := envScript(tt.script, tt.dir, "marker")
+			if !strings.Contains(got, "$null = & {\n"+tt.want+"\n}\n") {
+				t.Errorf("envScript(%q, %q) = %q, want it to run %q", tt.script, tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputEnvRelativeFile(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "setup.ps1"), []byte("$env:SPAWN_SETUP = 'done'\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := NewOptions().WithDir(dir).OutputEnv("setup.ps1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if env["SPAWN_SETUP"] != "done" {
+		t.Errorf("SPAWN_SETUP = %q, want %q", env["SPAWN_SETUP"], "done")
+	}
+}
@@ -0,0 +1,43 @@
+package powershell
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jolt9dev/go-platform"
+)
+
+// ErrShellNotFound is returned when the powershell executable
+// cannot be found and lists the locations that were probed.
+type ErrShellNotFound struct {
+	Name       string
+	Candidates []string
+}
+
+func (e *ErrShellNotFound) Error() string {
+	return fmt.Sprintf("%s not found, searched %s and PATH", e.Name, strings.Join(e.Candidates, ", "))
+}
+
+// Returns the path to the pwsh executable, falling back to
+// powershell.exe on Windows, or an *ErrShellNotFound error that
+// lists the locations that were probed.
+//
+// Example:
+//
+//	exe, err := powershell.WhichE()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func WhichE() (string, error) {
+	exe := Which()
+	if exe == "" {
+		name := "pwsh"
+		if platform.IsWindows() {
+			name = "pwsh or powershell"
+		}
+
+		return "", &ErrShellNotFound{Name: name, Candidates: Candidates()}
+	}
+
+	return exe, nil
+}
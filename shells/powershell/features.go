@@ -0,0 +1,21 @@
+package powershell
+
+import "github.com/jolt9dev/go-spawn/shells"
+
+// Returns the features of the resolved powershell, which has arrays
+// but none of the POSIX shell options. Options.StopOnError and
+// Options.ErrorAsFailure cover what set -e does for POSIX shells
+// and Set-StrictMode what set -u does. The zero value is returned
+// when powershell is not installed.
+//
+// Example:
+//
+//	f := powershell.Features()
+//	log.Println("arrays:", f.Arrays)
+func Features() shells.ShellFeatures {
+	if Which() == "" {
+		return shells.ShellFeatures{}
+	}
+
+	return shells.ShellFeatures{Arrays: true}
+}
@@ -0,0 +1,54 @@
+package powershell
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Runs a new powershell inline script or file with the input written
+// to stdin. Stdout and stderr are inherited from the current
+// process.
+//
+// Example:
+//
+//	powershell.RunWithInput("$input | Write-Host", "hello")
+func RunWithInput(script, input string) (*exec.PsOutput, error) {
+	return NewOptions().RunWithInput(script, input)
+}
+
+// Outputs a new powershell inline script or file with the input written
+// to stdin and captures stdout and stderr.
+//
+// Example:
+//
+//	out, err := powershell.OutputWithInput("$input | ForEach-Object { $_.ToUpper() }", "hello")
+func OutputWithInput(script, input string) (*exec.PsOutput, error) {
+	return NewOptions().OutputWithInput(script, input)
+}
+
+// Runs the inline script or file with the input written to stdin
+func (o *Options) RunWithInput(script, input string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Wait(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
+
+// Runs the inline script or file with the input written to stdin
+// and captures stdout and stderr
+func (o *Options) OutputWithInput(script, input string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	cmd.Stdin = strings.NewReader(input)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Output(ctx, cmd)
+	return o.normalize(out), o.finish(cmd, err)
+}
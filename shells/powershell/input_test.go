@@ -0,0 +1,36 @@
+package powershell
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOutputWithInput(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	tests := []struct {
+		name   string
+		script string
+		input  string
+		want   string
+	}{
+		{"input", "$input | ForEach-Object { $_.ToUpper() }", "hello\nworld\n", "HELLO\nWORLD"},
+		{"read line", "Write-Output ('got ' + [Console]::In.ReadLine())", "a b\nc\n", "got a b"},
+		{"empty", "@($input).Count", "", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := OutputWithInput(tt.script, tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.TrimSpace(string(out.Stdout)); got != tt.want {
+				t.Errorf("stdout = %q, want %q, stderr = %q", got, tt.want, out.Stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package powershell
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jolt9dev/go-spawn/internal/psscript"
+	"github.com/jolt9dev/go-xstrings"
+)
+
+// Outputs a new powershell inline script or file, converts the
+// result to json with ConvertTo-Json and unmarshals stdout into v.
+// The output of every statement is converted, and ConvertTo-Json is
+// only added when the script does not already use it. When v is a
+// pointer to a slice, a single object result is wrapped in an array
+// since powershell does not emit an array for a single element.
+//
+// Example:
+//
+//	var procs []struct{ Name string; Id int }
+//	err := powershell.OutputJSON("Get-Process | Select-Object Name, Id", &procs)
+func OutputJSON(script string, v any) error {
+	return NewOptions().OutputJSON(script, v)
+}
+
+// Outputs the inline script or file and unmarshals the json
+// result into v
+func (o *Options) OutputJSON(script string, v any) error {
+	out, err := o.Output(jsonScript(script, o.Dir))
+	if out != nil && out.Code != 0 {
+		stderr := strings.TrimSpace(string(out.Stderr))
+		return fmt.Errorf("powershell failed with code %d: %s", out.Code, stderr)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	data := bytes.TrimSpace(psscript.TrimBom(out.Stdout))
+	if len(data) == 0 {
+		data = []byte("null")
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Slice && data[0] != '[' && !bytes.Equal(data, []byte("null")) {
+		data = append(append([]byte{'['}, data...), ']')
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// returns the script with its whole output piped to ConvertTo-Json
+// unless it already converts it. The script runs in a script block
+// so that every statement is converted and a trailing comment does
+// not comment out the pipe. A script file is invoked by its
+// absolute path resolved against dir.
+func jsonScript(script, dir string) string {
+	if file, ok := psscript.File(script); ok {
+		script = "& " + Quote(psscript.AbsFile(dir, file))
+	}
+
+	script = strings.TrimSpace(script)
+
+	if xstrings.ContainsFold(script, "ConvertTo-Json") {
+		return script
+	}
+
+	return "& {\n" + script + "\n} | ConvertTo-Json -Depth 100"
+}
@@ -0,0 +1,75 @@
+package powershell
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONScript(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		script string
+		dir    string
+		want   string
+	}{
+		{"pipeline", "Get-Item .", "", "& {\nGet-Item .\n} | ConvertTo-Json -Depth 100"},
+		{"statements", "$a = 1; $a\n$a + 1", "", "& {\n$a = 1; $a\n$a + 1\n} | ConvertTo-Json -Depth 100"},
+		{"trailing comment", "Get-Date # now", "", "& {\nGet-Date # now\n} | ConvertTo-Json -Depth 100"},
+		{"file", " ./list.ps1 ", "", "& {\n& " + Quote(filepath.Join(cwd, "list.ps1")) + "\n} | ConvertTo-Json -Depth 100"},
+		{"bare file", "list.ps1", "", "& {\n& " + Quote(filepath.Join(cwd, "list.ps1")) + "\n} | ConvertTo-Json -Depth 100"},
+		{"file in dir", "list.ps1", dir, "& {\n& " + Quote(filepath.Join(dir, "list.ps1")) + "\n} | ConvertTo-Json -Depth 100"},
+		{"absolute file", filepath.Join(dir, "a b.ps1"), cwd, "& {\n& " + Quote(filepath.Join(dir, "a b.ps1")) + "\n} | ConvertTo-Json -Depth 100"},
+		{"already converted", "Get-Date | ConvertTo-Json -Compress", "", "Get-Date | ConvertTo-Json -Compress"},
+		{"case insensitive", "Get-Date | convertto-json", "", "Get-Date | convertto-json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonScript(tt.script, tt.dir); got != tt.want {
+				t.Errorf("jsonScript(%q, %q) = %q, want %q", tt.script, tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputJSON(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	var values []int
+	if err := OutputJSON("1\n2 # two\n3", &values); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(values) != 3 || values[0] != 1 || values[2] != 3 {
+		t.Errorf("values = %v, want [1 2 3]", values)
+	}
+}
+
+func TestOutputJSONRelativeFile(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "list.ps1"), []byte("1\n2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var values []int
+	if err := NewOptions().WithDir(dir).OutputJSON("list.ps1", &values); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Errorf("values = %v, want [1 2]", values)
+	}
+}
@@ -0,0 +1,32 @@
+package powershell
+
+import (
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// ErrOutputTruncated is returned by Output and the other functions
+// that capture output when stdout or stderr exceeded MaxOutputBytes.
+// The output captured up to the limit is returned along with the
+// error. exec.PsOutput has no truncated flag, so callers must check
+// the error with errors.Is(err, ErrOutputTruncated) to tell a
+// truncated output from a complete one.
+var ErrOutputTruncated = proc.ErrOutputTruncated
+
+// The default maximum number of bytes captured per stream, see
+// Options.MaxOutputBytes. Zero or less means no limit.
+var MaxOutputBytes = 0
+
+// Sets the maximum number of bytes captured per stream. Once a
+// stream exceeds the limit, the process tree is killed and the
+// output captured so far is returned with ErrOutputTruncated.
+//
+// Example:
+//
+//	out, err := powershell.NewOptions().WithMaxOutputBytes(1 << 20).Output(script)
+//	if errors.Is(err, powershell.ErrOutputTruncated) {
+//		log.Printf("output truncated to %d bytes", len(out.Stdout))
+//	}
+func (o *Options) WithMaxOutputBytes(n int) *Options {
+	o.MaxOutputBytes = n
+	return o
+}
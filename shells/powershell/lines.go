@@ -0,0 +1,43 @@
+package powershell
+
+import (
+	"strings"
+
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Outputs a new powershell inline script or file and returns stdout
+// split into trimmed lines without trailing empty lines. The
+// lines captured before a failure are returned with the error.
+//
+// Example:
+//
+//	files, err := powershell.OutputLines("Get-ChildItem -Name")
+func OutputLines(script string) ([]string, error) {
+	out, err := Output(script)
+	return proc.Lines(out.Stdout), err
+}
+
+// Outputs a new powershell inline script or file and returns stdout
+// with surrounding whitespace trimmed.
+//
+// Example:
+//
+//	branch, err := powershell.OutputText("(Get-Location).Path")
+func OutputText(script string) (string, error) {
+	out, err := Output(script)
+	return strings.TrimSpace(string(out.Stdout)), err
+}
+
+// Outputs a new powershell inline script or file and returns stdout
+// with surrounding whitespace trimmed. When the script fails or
+// exits with a non-zero code, an error is returned that includes
+// the exit code and stderr.
+//
+// Example:
+//
+//	version, err := powershell.MustOutput("$PSVersionTable.PSVersion.ToString()")
+func MustOutput(script string) (string, error) {
+	out, err := Output(script)
+	return proc.Text("powershell", out, err)
+}
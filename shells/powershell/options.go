@@ -0,0 +1,326 @@
+package powershell
+
+import (
+	"context"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+	"github.com/jolt9dev/go-spawn/internal/psscript"
+)
+
+// When true, inline scripts are prefixed with
+// $ErrorActionPreference = 'Stop' so that cmdlet errors
+// terminate the script with a non-zero exit code.
+var StopOnError = true
+
+// When true, inline scripts exit with 1 when any error was
+// written to the error stream, even when the error did not
+// terminate the script. See Options.ErrorAsFailure.
+var ErrorAsFailure = false
+
+// The execution policy passed with -ExecutionPolicy on Windows
+// so that script files run on locked down hosts. Set to an
+// empty string to use the policy configured on the host.
+var ExecutionPolicy = "Bypass"
+
+// Options controls how powershell inline scripts and files
+// are invoked. Use NewOptions to create options initialized
+// from the package level defaults.
+type Options struct {
+	// Prepends $ErrorActionPreference = 'Stop' and appends
+	// an exit with $LASTEXITCODE to inline scripts. This only
+	// applies to -Command and -EncodedCommand, files run with
+	// -File must set the preference variable themselves since
+	// the script runs in its own scope.
+	StopOnError bool
+
+	// Exits inline scripts with the last native exit code or
+	// with 1 when any error was recorded in $Error, e.g. by
+	// Write-Error or a cmdlet that failed without terminating
+	// the script. Errors that were caught with try/catch are
+	// recorded too, call $Error.Clear() after handling them.
+	ErrorAsFailure bool
+
+	// Passes inline scripts with characters that break command
+	// line quoting using -EncodedCommand.
+	UseEncodedCommand bool
+
+	// The value passed with -ExecutionPolicy. The flag is
+	// omitted when empty or when not running on Windows since
+	// execution policies are not enforced on other platforms.
+	ExecutionPolicy string
+
+	// Environment variables merged onto the current process
+	// environment.
+	Env map[string]string
+
+	// When true, the current process environment is not
+	// inherited and only Env is passed to the command.
+	ClearEnv bool
+
+	// Keeps the temp file written for an inline script on
+	// disk when the command fails.
+	KeepTempOnError bool
+
+	// The directory temp scripts are written to. When empty, the
+	// default temp directory of the os is used.
+	TempDir string
+
+	// The value passed with -OutputFormat, either Text or Xml.
+	// The flag is omitted when empty.
+	OutputFormat string
+
+	// The encoding of the console output set at the start of
+	// inline scripts such as utf-8. When empty, the encoding of
+	// the host is used.
+	OutputEncoding string
+
+	// Decodes UTF-16 output, strips a leading byte order mark and
+	// normalizes CRLF line endings to LF in captured output.
+	NormalizeOutput bool
+
+	// The working directory of the command which must exist
+	// before the command is started. Inline scripts also start
+	// with Set-Location so that $PWD matches.
+	Dir string
+
+	// Loads the powershell profiles of the user and host, which
+	// are skipped with -NoProfile by default.
+	Profile bool
+
+	// Traces each line of inline scripts with Set-PSDebug
+	// -Trace 1. The trace is written to the host output.
+	Debug bool
+
+	// The maximum duration of the command. The process tree is
+	// killed once exceeded. Zero or less means no limit.
+	Timeout time.Duration
+
+	// The time RunTimeout waits for the process to exit after
+	// asking it to terminate before the process tree is killed.
+	GracePeriod time.Duration
+
+	// The maximum number of bytes captured per stream by Output and
+	// the other functions that capture output. The process tree is
+	// killed once exceeded and the error wraps ErrOutputTruncated.
+	// Zero or less means no limit.
+	MaxOutputBytes int
+
+	// When true, the interrupt and terminate signals received by
+	// the current process are forwarded to the process group of
+	// the command while it runs instead of terminating the current
+	// process, see WithForwardSignals.
+	ForwardSignals bool
+
+	// What Run and RunContext do with stdout and stderr, see
+	// RunMode. Defaults to DefaultRunMode.
+	RunMode RunMode
+
+	// The COM apartment powershell starts in, either STA or MTA,
+	// passed with -Sta or -Mta on Windows. When empty, the default
+	// of the resolved powershell is used.
+	ApartmentState string
+
+	// The value passed with -WindowStyle on Windows, such as
+	// Hidden. Hidden also starts the process without a console
+	// window. Ignored on other platforms.
+	WindowStyle string
+}
+
+// Creates new options initialized from the package
+// level defaults and Defaults
+func NewOptions() *Options {
+	return &Options{
+		StopOnError:       StopOnError,
+		ErrorAsFailure:    ErrorAsFailure,
+		NormalizeOutput:   NormalizeOutput,
+		OutputEncoding:    OutputEncoding,
+		UseEncodedCommand: UseEncodedCommand,
+		ExecutionPolicy:   ExecutionPolicy,
+		KeepTempOnError:   KeepTempOnError,
+		TempDir:           TempDir,
+		Dir:               Defaults.Cwd,
+		Env:               defaultEnv(),
+		Timeout:           Defaults.Timeout,
+		GracePeriod:       GracePeriod,
+		MaxOutputBytes:    MaxOutputBytes,
+		ForwardSignals:    ForwardSignals,
+		RunMode:           DefaultRunMode,
+	}
+}
+
+// Creates new options from the package level defaults
+// with StopOnError set to the given value.
+//
+// Example:
+//
+//	powershell.WithStopOnError(false).Run("Get-Item missing; Write-Host done")
+func WithStopOnError(stop bool) *Options {
+	return NewOptions().WithStopOnError(stop)
+}
+
+// Creates new options from the package level defaults
+// with the given execution policy.
+//
+// Example:
+//
+//	powershell.WithExecutionPolicy("RemoteSigned").File("script.ps1").Run()
+func WithExecutionPolicy(policy string) *Options {
+	return NewOptions().WithExecutionPolicy(policy)
+}
+
+// Creates new options from the package level defaults
+// that load the powershell profiles when load is true.
+// Profiles can set up modules and aliases a script relies
+// on, but make runs depend on the machine they run on.
+//
+// Example:
+//
+//	powershell.WithProfile(true).Run("Invoke-MyAlias")
+func WithProfile(load bool) *Options {
+	return NewOptions().WithProfile(load)
+}
+
+// Sets whether the powershell profiles are loaded
+func (o *Options) WithProfile(load bool) *Options {
+	o.Profile = load
+	return o
+}
+
+// Sets the execution policy passed with -ExecutionPolicy
+func (o *Options) WithExecutionPolicy(policy string) *Options {
+	o.ExecutionPolicy = policy
+	return o
+}
+
+// Creates new options from the package level defaults
+// with ErrorAsFailure set to the given value.
+//
+// Example:
+//
+//	powershell.WithErrorAsFailure(true).Run("Get-Item missing; Write-Host done")
+func WithErrorAsFailure(fail bool) *Options {
+	return NewOptions().WithErrorAsFailure(fail)
+}
+
+// Sets whether inline scripts fail when any error was written
+// to the error stream
+func (o *Options) WithErrorAsFailure(fail bool) *Options {
+	o.ErrorAsFailure = fail
+	return o
+}
+
+// Sets whether inline scripts stop on the first error
+func (o *Options) WithStopOnError(stop bool) *Options {
+	o.StopOnError = stop
+	return o
+}
+
+// Sets whether inline scripts may be passed using
+// -EncodedCommand
+func (o *Options) WithEncodedCommand(encoded bool) *Options {
+	o.UseEncodedCommand = encoded
+	return o
+}
+
+// Creates a new powershell command with the given script file
+func (o *Options) File(file string) *exec.Cmd {
+	return o.shared().File(host, file)
+}
+
+// Creates a new powershell command with the given inline script
+// or file. However, the file must have a .ps1 extension
+// and be on a single line. Scripts with here-strings are passed
+// with -EncodedCommand, which keeps them intact without leaving a
+// temp file behind when the command is run directly. Run, Output
+// and the other functions that run the script write them to a
+// temp .ps1 file instead, run it with -File and remove it after
+// the command completes.
+func (o *Options) Script(script string) *exec.Cmd {
+	return o.shared().Script(host, script, false)
+}
+
+// creates the command for the run paths, which may write the
+// script to a temp file that is removed by finish
+func (o *Options) runScript(script string) *exec.Cmd {
+	return o.shared().Script(host, script, true)
+}
+
+// Creates a new powershell command with the given inline script
+// passed using -EncodedCommand
+func (o *Options) ScriptEncoded(script string) *exec.Cmd {
+	s := o.shared()
+	return s.Encoded(host, s.Inline(script))
+}
+
+// Runs the inline script or file with stdout and stderr
+// inherited from the current process unless the run mode
+// of the options is set otherwise
+func (o *Options) Run(script string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := o.run(ctx, cmd)
+	return out, o.finish(cmd, err)
+}
+
+// Runs the inline script or file and captures stdout
+// and stderr
+func (o *Options) Output(script string) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Output(ctx, cmd)
+	return o.normalize(out), o.finish(cmd, err)
+}
+
+// creates the command with the flags derived from the options
+// followed by the given arguments
+func (o *Options) command(args ...string) *exec.Cmd {
+	return o.shared().Command(host, args...)
+}
+
+// returns the options shared with the winpowershell package
+func (o *Options) shared() *psscript.Options {
+	return &psscript.Options{
+		StopOnError:       o.StopOnError,
+		ErrorAsFailure:    o.ErrorAsFailure,
+		UseEncodedCommand: o.UseEncodedCommand,
+		ExecutionPolicy:   o.ExecutionPolicy,
+		ApartmentState:    o.ApartmentState,
+		WindowStyle:       o.WindowStyle,
+		OutputFormat:      o.OutputFormat,
+		OutputEncoding:    o.OutputEncoding,
+		NormalizeOutput:   o.NormalizeOutput,
+		Profile:           o.Profile,
+		Debug:             o.Debug,
+		Dir:               o.Dir,
+		Env:               o.Env,
+		ClearEnv:          o.ClearEnv,
+		TempDir:           o.TempDir,
+		KeepTempOnError:   o.KeepTempOnError,
+		Timeout:           o.Timeout,
+		MaxOutputBytes:    o.MaxOutputBytes,
+		ForwardSignals:    o.ForwardSignals,
+	}
+}
+
+// describes pwsh, or powershell.exe when pwsh is not installed, to
+// the options shared with the winpowershell package
+var host = &psscript.Host{
+	Name: "powershell",
+	Which: func() (string, error) {
+		exe, err := WhichE()
+		if err != nil {
+			return WhichOrDefault(), err
+		}
+
+		return exe, nil
+	},
+	ApartmentFlag:     apartmentFlag,
+	ErrApartmentState: ErrApartmentState,
+	TempFiles:         &tempFiles,
+	BeforeRun:         beforeRun,
+	Runner:            runner,
+}
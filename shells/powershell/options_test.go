@@ -0,0 +1,206 @@
+package powershell
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jolt9dev/go-platform"
+)
+
+func TestFileExecutionPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   *Options
+		policy string
+	}{
+		{"default", NewOptions(), "Bypass"},
+		{"custom", WithExecutionPolicy("RemoteSigned"), "RemoteSigned"},
+		{"host policy", WithExecutionPolicy(""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.opts.File("script.ps1").Args
+			i := slices.Index(args, "-ExecutionPolicy")
+			if tt.policy == "" || !platform.IsWindows() {
+				if i >= 0 {
+					t.Errorf("args %v contain -ExecutionPolicy", args)
+				}
+
+				return
+			}
+
+			if i < 0 || i+1 >= len(args) || args[i+1] != tt.policy {
+				t.Errorf("args %v do not contain -ExecutionPolicy %s", args, tt.policy)
+			}
+
+			if f := slices.Index(args, "-File"); f < i {
+				t.Errorf("args %v pass -ExecutionPolicy after -File", args)
+			}
+		})
+	}
+}
+
+func TestFileQuoting(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"spaces", "my script.ps1"},
+		{"single quote", "it's.ps1"},
+		{"double quote", `say "hi".ps1`},
+		{"dollar", "$env.ps1"},
+		{"backtick", "a`b.ps1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := File(tt.file).Args
+			if len(args) < 2 || args[len(args)-2] != "-File" || args[len(args)-1] != tt.file {
+				t.Errorf("args %v do not end with -File %q", args, tt.file)
+			}
+		})
+	}
+}
+
+func TestFileRunsOddNames(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"spaces", "my script.ps1"},
+		{"single quote", "it's.ps1"},
+		{"dollar", "$env.ps1"},
+		{"backtick", "a`b.ps1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(file, []byte("Write-Output 'ran'\n"), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			out, err := File(file).Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.TrimSpace(string(out.Stdout)); got != "ran" {
+				t.Errorf("stdout = %q, stderr = %q", out.Stdout, out.Stderr)
+			}
+		})
+	}
+}
+
+func TestScriptQuoting(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"single quotes", `Write-Output 'it''s'`, "it's"},
+		{"double quotes", `Write-Output "a ""b"""`, `a "b"`},
+		{"dollar", `$x = 'v'; Write-Output "$x"`, "v"},
+		{"escaped dollar", "Write-Output \"`$x\"", "$x"},
+		{"backtick escapes", "Write-Output \"a`tb\"", "a\tb"},
+		{"newlines", "$a = 1\n$b = 2\nWrite-Output ($a + $b)", "3"},
+		{"crlf", "$a = 1\r\nWrite-Output $a", "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// double quotes do not survive the command line of
+			// powershell.exe without -EncodedCommand
+			out, err := NewOptions().WithEncodedCommand(true).Output(tt.script)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.TrimSpace(string(out.Stdout)); got != tt.want {
+				t.Errorf("stdout = %q, want %q, stderr = %q", got, tt.want, out.Stderr)
+			}
+		})
+	}
+}
+
+func TestFlagsProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *Options
+		want    []string
+		without []string
+	}{
+		{"default", NewOptions(), []string{"-NoLogo", "-NoProfile", "-NonInteractive"}, nil},
+		{"profile", NewOptions().WithProfile(true), []string{"-NoLogo", "-NonInteractive"}, []string{"-NoProfile"}},
+		{"no profile", NewOptions().WithProfile(false), []string{"-NoProfile"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, cmd := range [][]string{tt.opts.File("a.ps1").Args, tt.opts.Script("Get-Date").Args} {
+				for _, flag := range tt.want {
+					if !slices.Contains(cmd, flag) {
+						t.Errorf("args %v do not contain %s", cmd, flag)
+					}
+				}
+
+				for _, flag := range tt.without {
+					if slices.Contains(cmd, flag) {
+						t.Errorf("args %v contain %s", cmd, flag)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestScriptQuotingExtended(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"typographic quotes", "Write-Output " + Quote("it’s ‘quoted’"), "it’s ‘quoted’"},
+		{"quoted newline", "Write-Output " + Quote("a\nb"), "a\nb"},
+		{"quoted dollar and backtick", "Write-Output " + Quote("$x `n"), "$x `n"},
+		{"semicolons and pipes", `Write-Output 'a;b|c&d'`, "a;b|c&d"},
+		{"percent and caret", `Write-Output '%PATH% ^'`, "%PATH% ^"},
+		{"redirect characters", `Write-Output '<a> b'`, "<a> b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, encoded := range []bool{false, true} {
+				// the command line of powershell.exe mangles these
+				if !encoded && platform.IsWindows() {
+					continue
+				}
+
+				out, err := NewOptions().WithEncodedCommand(encoded).WithOutputEncoding("utf-8").Output(tt.script)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got := strings.TrimSpace(string(out.Stdout)); got != tt.want {
+					t.Errorf("encoded %v: stdout = %q, want %q, stderr = %q", encoded, got, tt.want, out.Stderr)
+				}
+			}
+		})
+	}
+}
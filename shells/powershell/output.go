@@ -0,0 +1,70 @@
+package powershell
+
+import (
+	"github.com/jolt9dev/go-exec"
+)
+
+// When true, the stdout and stderr captured by Output and the other
+// functions that capture output are normalized: UTF-16 output with
+// a byte order mark is decoded to UTF-8, a leading UTF-8 byte order
+// mark is removed and CRLF line endings are replaced with LF, which
+// Windows PowerShell emits depending on the host encoding. Use
+// OutputRaw for the bytes as written by the process.
+var NormalizeOutput = true
+
+// The encoding of the console output set at the start of inline
+// scripts, see Options.WithOutputEncoding. When empty, the encoding
+// of the host is used.
+var OutputEncoding = ""
+
+// Outputs a new powershell inline script or file and captures
+// stdout and stderr as written by the process, without the
+// normalization of NormalizeOutput.
+//
+// Example:
+//
+//	out, err := powershell.OutputRaw("Get-Content -Raw -Encoding Byte data.bin")
+func OutputRaw(script string) (*exec.PsOutput, error) {
+	return NewOptions().OutputRaw(script)
+}
+
+// Outputs the inline script or file and captures stdout and
+// stderr as written by the process
+func (o *Options) OutputRaw(script string) (*exec.PsOutput, error) {
+	opts := *o
+	opts.NormalizeOutput = false
+	return opts.Output(script)
+}
+
+// Creates new options from the package level defaults with the
+// given output encoding. See Options.WithOutputEncoding.
+//
+// Example:
+//
+//	out, err := powershell.WithOutputEncoding("utf-8").Output("Write-Output 'café'")
+func WithOutputEncoding(name string) *Options {
+	return NewOptions().WithOutputEncoding(name)
+}
+
+// Sets the encoding of the console output, e.g. utf-8, which inline
+// scripts set with [Console]::OutputEncoding and $OutputEncoding
+// before the script runs. Windows PowerShell uses the legacy code
+// page of the host by default, which garbles non-ASCII output.
+// utf-8 is set without a byte order mark, other names are passed
+// to [System.Text.Encoding]::GetEncoding. Files run with -File are
+// not affected.
+func (o *Options) WithOutputEncoding(name string) *Options {
+	o.OutputEncoding = name
+	return o
+}
+
+// Sets whether captured output is normalized, see NormalizeOutput
+func (o *Options) WithNormalizeOutput(normalize bool) *Options {
+	o.NormalizeOutput = normalize
+	return o
+}
+
+// normalizes the captured streams when enabled
+func (o *Options) normalize(out *exec.PsOutput) *exec.PsOutput {
+	return o.shared().Normalize(out)
+}
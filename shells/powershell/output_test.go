@@ -0,0 +1,73 @@
+package powershell
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+func TestNormalize(t *testing.T) {
+	raw := []byte("\ufeffa\r\nb\r\n")
+	tests := []struct {
+		name string
+		opts *Options
+		want string
+	}{
+		{"default", NewOptions(), "a\nb\n"},
+		{"disabled", NewOptions().WithNormalizeOutput(false), string(raw)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := tt.opts.normalize(&exec.PsOutput{Stdout: append([]byte{}, raw...), Stderr: append([]byte{}, raw...)})
+			if string(out.Stdout) != tt.want || string(out.Stderr) != tt.want {
+				t.Errorf("stdout = %q, stderr = %q, want %q", out.Stdout, out.Stderr, tt.want)
+			}
+		})
+	}
+
+	if NewOptions().normalize(nil) != nil {
+		t.Error("normalize(nil) is not nil")
+	}
+}
+
+func TestOutputEncodingPrefix(t *testing.T) {
+	cmd := WithOutputEncoding("utf-8").Script("Write-Output 'é'")
+	script := cmd.Args[len(cmd.Args)-1]
+	want := "[Console]::OutputEncoding = (New-Object System.Text.UTF8Encoding $false)\n$OutputEncoding = [Console]::OutputEncoding\n"
+	if !strings.Contains(script, want) {
+		t.Errorf("script = %q, want it to contain %q", script, want)
+	}
+}
+
+func TestOutputEncodingRoundTrip(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	const text = "café é ü 日本語 한국어"
+	tests := []struct {
+		name   string
+		opts   *Options
+		script string
+	}{
+		{"command", WithOutputEncoding("utf-8"), "Write-Output '" + text + "'"},
+		{"encoded command", WithOutputEncoding("utf-8").WithEncodedCommand(true), "Write-Output \"" + text + "\""},
+		{"console write", WithOutputEncoding("utf-8"), "[Console]::Out.Write('" + text + "')"},
+		{"here-string", WithOutputEncoding("utf-8"), "Write-Output @'\n" + text + "\n'@"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.opts.Output(tt.script)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.TrimRight(string(out.Stdout), "\n"); got != text {
+				t.Errorf("stdout = %q, want %q, stderr = %q", got, text, out.Stderr)
+			}
+		})
+	}
+}
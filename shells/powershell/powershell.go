@@ -0,0 +1,287 @@
+package powershell
+
+import (
+	osexec "os/exec"
+	"sync"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-platform"
+	"github.com/jolt9dev/go-spawn/internal/lookup"
+)
+
+var whichCache struct {
+	sync.Mutex
+	core    string
+	windows string
+}
+
+func init() {
+	exec.Register("pwsh", &exec.Executable{
+		Name:     "pwsh",
+		Variable: "PWSH_PATH",
+		Windows: []string{
+			"${ProgramFiles}\\PowerShell\\7\\pwsh.exe",
+			"${ProgramFiles(x86)}\\PowerShell\\7\\pwsh.exe",
+			"${LOCALAPPDATA}\\Microsoft\\WindowsApps\\pwsh.exe",
+		},
+		Linux: []string{
+			"/usr/bin/pwsh",
+			"/usr/local/bin/pwsh",
+			"/opt/microsoft/powershell/7/pwsh",
+			"/snap/bin/pwsh",
+		},
+		Darwin: []string{
+			"/usr/local/bin/pwsh",
+			"/opt/homebrew/bin/pwsh",
+			"/usr/local/microsoft/powershell/7/pwsh",
+		},
+	})
+
+	exec.Register("powershell", &exec.Executable{
+		Name:     "powershell",
+		Variable: "POWERSHELL_PATH",
+		Windows: []string{
+			"${SystemRoot}\\System32\\WindowsPowerShell\\v1.0\\powershell.exe",
+			"${SystemRoot}\\SysWOW64\\WindowsPowerShell\\v1.0\\powershell.exe",
+		},
+	})
+}
+
+// Returns the path to the pwsh executable, falling back to
+// powershell.exe on Windows, or an empty string. Each is resolved
+// in order from its variable, PWSH_PATH or POWERSHELL_PATH, which
+// may be quoted and contain variables such as ${ProgramFiles},
+// then the known locations and then the PATH. WhichOrDefault
+// falls back to the bare name.
+func Which() string {
+	exe := WhichCore()
+	if exe == "" {
+		exe = WhichWindows()
+	}
+
+	return exe
+}
+
+// Returns the path to the PowerShell Core (pwsh) executable
+// or an empty string. When none of the known locations exist,
+// the PATH is searched. The resolved path is cached until
+// ResetWhichCache is called.
+func WhichCore() string {
+	whichCache.Lock()
+	defer whichCache.Unlock()
+
+	if whichCache.core != "" {
+		return whichCache.core
+	}
+
+	exe := WhichFromEnv()
+	if exe == "" {
+		exe, _ = exec.Find("pwsh")
+	}
+
+	if exe == "" {
+		exe, _ = osexec.LookPath("pwsh")
+	}
+
+	whichCache.core = exe
+	return exe
+}
+
+// Returns the unquoted and expanded path from PWSH_PATH when it
+// is an existing file, otherwise an empty string. POWERSHELL_PATH
+// is only used by WhichWindows.
+//
+// Example:
+//
+//	if powershell.WhichFromEnv() == "" {
+//		log.Println("PWSH_PATH is not set, probing for pwsh")
+//	}
+func WhichFromEnv() string {
+	return lookup.FromEnv("PWSH_PATH")
+}
+
+// Returns the path to the Windows PowerShell (powershell.exe)
+// executable or an empty string. When none of the known
+// locations exist, the PATH is searched. Always returns an
+// empty string on non-Windows platforms. The resolved path is
+// cached until ResetWhichCache is called.
+func WhichWindows() string {
+	if !platform.IsWindows() {
+		return ""
+	}
+
+	whichCache.Lock()
+	defer whichCache.Unlock()
+
+	if whichCache.windows != "" {
+		return whichCache.windows
+	}
+
+	exe := lookup.FromEnv("POWERSHELL_PATH")
+	if exe == "" {
+		exe, _ = exec.Find("powershell")
+	}
+
+	if exe == "" {
+		exe, _ = osexec.LookPath("powershell")
+	}
+
+	whichCache.windows = exe
+	return exe
+}
+
+// Clears the cached paths resolved by WhichCore and WhichWindows
+// so that the next call probes the file system again, e.g. after
+// PATH changes.
+func ResetWhichCache() {
+	whichCache.Lock()
+	defer whichCache.Unlock()
+	whichCache.core = ""
+	whichCache.windows = ""
+}
+
+// Returns the path to the powershell executable or the default
+// which is the name of the executable without a path or
+// extension. pwsh is preferred and powershell is only used
+// as the default on Windows.
+func WhichOrDefault() string {
+	exe := Which()
+	if exe == "" {
+		if platform.IsWindows() {
+			return "powershell"
+		}
+
+		return "pwsh"
+	}
+
+	return exe
+}
+
+// Creates a new powershell command with the given arguments
+// using vardiac arguments
+//
+// Example:
+//
+//	powershell.New("-NoProfile", "-Command", "Write-Host hello").Run()
+func New(args ...string) *exec.Cmd {
+	return applyDefaults(exec.New(WhichOrDefault(), args...))
+}
+
+// Creates a new PowerShell Core (pwsh) command with the given
+// arguments using vardiac arguments
+//
+// Example:
+//
+//	powershell.NewCore("-NoProfile", "-Command", "Write-Host hello").Run()
+func NewCore(args ...string) *exec.Cmd {
+	exe := WhichCore()
+	if exe == "" {
+		exe = "pwsh"
+	}
+
+	return applyDefaults(exec.New(exe, args...))
+}
+
+// Creates a new Windows PowerShell (powershell.exe) command with
+// the given arguments using vardiac arguments
+//
+// Example:
+//
+//	powershell.NewWindows("-NoProfile", "-Command", "Write-Host hello").Run()
+func NewWindows(args ...string) *exec.Cmd {
+	exe := WhichWindows()
+	if exe == "" {
+		exe = "powershell"
+	}
+
+	return applyDefaults(exec.New(exe, args...))
+}
+
+// Creates a new powershell command with the given arguments
+// using a single string which is split with SplitArgs using
+// powershell quoting rules. An error from SplitArgs is returned
+// when the command is run.
+//
+// Example:
+//
+//	powershell.Command("-NoProfile -Command 'Write-Host hello'").Run()
+//	powershell.Command(`-Command "Get-Item 'a b'"`).Run()
+func Command(args string) *exec.Cmd {
+	split, err := SplitArgs(args)
+	cmd := applyDefaults(exec.New(WhichOrDefault(), split...))
+	if err != nil {
+		cmd.Err = err
+	}
+
+	return cmd
+}
+
+// Creates a new powershell command with the given arguments that
+// are already split, so values with embedded spaces are passed
+// as is.
+//
+// Example:
+//
+//	powershell.CommandArgs([]string{"-NoProfile", "-File", "C:\\My Scripts\\build.ps1"}).Run()
+func CommandArgs(args []string) *exec.Cmd {
+	return applyDefaults(exec.New(WhichOrDefault(), args...))
+}
+
+// Creates a new powershell command with the given script file
+//
+// Example:
+//
+//	powershell.File("script.ps1").Run()
+func File(file string) *exec.Cmd {
+	return NewOptions().File(file)
+}
+
+// Creates a new powershell command with the given inline script
+// or file. However, the file must have a .ps1 extension
+// and be on a single line. When UseEncodedCommand is true,
+// scripts with characters that break command line quoting
+// are passed using -EncodedCommand. When StopOnError is true,
+// inline scripts stop on the first cmdlet error. Scripts with
+// here-strings are passed using -EncodedCommand, while Run and
+// Output write them to a temp .ps1 file that is removed after
+// the command completes.
+//
+// Example:
+//
+//	powershell.Script(`Get-ChildItem |
+//	  Select-Object Name`).WithCwd("/path/to/dir").Run()
+//	powershell.Script("/path/to/script.ps1").Output()
+func Script(script string) *exec.Cmd {
+	return NewOptions().Script(script)
+}
+
+// Run a new powershell inline script or file.
+// When using a file, the file must have a .ps1 extension
+// and be on a single line.
+// Run will set stdout and stderr to inherit and not
+// capture the output, unless DefaultRunMode is changed.
+//
+// Example:
+//
+//	powershell.Run(`Get-ChildItem |
+//	  Select-Object Name`)
+//	powershell.Run("/path/to/script.ps1")
+func Run(script string) (*exec.PsOutput, error) {
+	return NewOptions().Run(script)
+}
+
+// Output a new powershell inline script or file.
+// When using a file, the file must have a .ps1 extension
+// and be on a single line.
+// Output will set stdout and stderr to piped and captures
+// the standard output and error streams
+//
+// Example:
+//
+//	out, err := powershell.Output("/path/to/script.ps1")
+//	if err != nil || out.Code != 0 {
+//	// handle error
+//	}
+func Output(script string) (*exec.PsOutput, error) {
+	return NewOptions().Output(script)
+}
@@ -0,0 +1,40 @@
+package powershell
+
+import (
+	"context"
+	"os"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Runs a new powershell inline script or file and writes each line of
+// stdout and stderr to the stdout and stderr of the current process
+// with the prefix prepended, e.g. to tell apart the output of
+// scripts that run concurrently. Partial lines are buffered until a
+// newline or until the process exits. Stdin is inherited.
+//
+// Example:
+//
+//	go powershell.RunPrefixed("./build.ps1", "[build] ")
+//	go powershell.RunPrefixed("./test.ps1", "[test] ")
+func RunPrefixed(script, prefix string) (*exec.PsOutput, error) {
+	return NewOptions().RunPrefixed(script, prefix)
+}
+
+// Runs the inline script or file with each line of stdout and
+// stderr prefixed
+func (o *Options) RunPrefixed(script, prefix string) (*exec.PsOutput, error) {
+	stdout := proc.NewPrefixWriter(os.Stdout, prefix)
+	stderr := proc.NewPrefixWriter(os.Stderr, prefix)
+	cmd := o.runScript(script)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Stdin = os.Stdin
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Wait(ctx, cmd)
+	stdout.Flush()
+	stderr.Flush()
+	return out, o.finish(cmd, err)
+}
@@ -0,0 +1,16 @@
+package powershell
+
+import "github.com/jolt9dev/go-spawn/internal/psscript"
+
+// Returns s quoted as a powershell single quoted string so that
+// it can be safely interpolated into script text. Single quotes,
+// including the typographic quotes powershell also accepts, are
+// escaped by doubling them. Quote is meant for building script
+// text, not for the arguments passed to Command.
+//
+// Example:
+//
+//	powershell.Run("Remove-Item -Recurse -LiteralPath " + powershell.Quote(dir))
+func Quote(s string) string {
+	return psscript.Quote(s)
+}
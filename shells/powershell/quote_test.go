@@ -0,0 +1,55 @@
+package powershell
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", "''"},
+		{"abc", "'abc'"},
+		{"it's", "'it''s'"},
+		{"''", "''''''"},
+		{"it’s", "'it’’s'"},
+		{"‘a’", "'‘‘a’’'"},
+		{"‚a‛", "'‚‚a‛‛'"},
+		{`"a"`, `'"a"'`},
+		{"$env:PATH", "'$env:PATH'"},
+		{"a`nb", "'a`nb'"},
+		{"$(Get-Date)", "'$(Get-Date)'"},
+		{"a\nb", "'a\nb'"},
+		{"a;b|c&d", "'a;b|c&d'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := Quote(tt.in); got != tt.want {
+				t.Errorf("Quote(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteRoundTrip(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	tests := []string{"", "a b", "it's", "it’s", `"a"`, "$env:PATH", "a`nb", "$(Get-Date)", "a\nb"}
+	for _, in := range tests {
+		t.Run(in, func(t *testing.T) {
+			out, err := NewOptions().WithEncodedCommand(true).WithOutputEncoding("utf-8").Output("[Console]::Out.Write(" + Quote(in) + ")")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.ReplaceAll(string(out.Stdout), "\r\n", "\n"); got != in {
+				t.Errorf("got %q, want %q", got, in)
+			}
+		})
+	}
+}
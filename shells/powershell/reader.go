@@ -0,0 +1,85 @@
+package powershell
+
+import (
+	"io"
+	"io/fs"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/psscript"
+)
+
+// Creates a new powershell command that runs the named script from
+// the file system, e.g. an embed.FS. The content is written to a
+// temp .ps1 file and run with -File as is, the same as File. The
+// temp file is removed by Cleanup.
+//
+// Example:
+//
+//	//go:embed scripts
+//	var scripts embed.FS
+//
+//	cmd, err := powershell.FileFS(scripts, "scripts/install.ps1")
+//	if err != nil {
+//		return err
+//	}
+//	defer powershell.Cleanup(cmd)
+//	cmd.Run()
+func FileFS(fsys fs.FS, name string) (*exec.Cmd, error) {
+	return NewOptions().FileFS(fsys, name)
+}
+
+// Creates a new powershell command that runs the script read from
+// r. The content is written to a temp file the same as FileFS.
+//
+// Example:
+//
+//	cmd, err := powershell.ScriptReader(resp.Body)
+//	if err != nil {
+//		return err
+//	}
+//	defer powershell.Cleanup(cmd)
+//	cmd.Run()
+func ScriptReader(r io.Reader) (*exec.Cmd, error) {
+	return NewOptions().ScriptReader(r)
+}
+
+// Creates a new powershell command that runs the named script
+// from the file system
+func (o *Options) FileFS(fsys fs.FS, name string) (*exec.Cmd, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.tempFile(string(data))
+}
+
+// Creates a new powershell command that runs the script read
+// from r
+func (o *Options) ScriptReader(r io.Reader) (*exec.Cmd, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.tempFile(string(data))
+}
+
+// writes the script to a temp file and creates a command that runs
+// it with -File, the temp file is removed when the command cannot
+// be created
+func (o *Options) tempFile(script string) (*exec.Cmd, error) {
+	file, err := psscript.WriteTemp(o.TempDir, script)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := o.File(file)
+	tempFiles.Track(cmd, file)
+	if cmd.Err != nil {
+		Cleanup(cmd)
+		return nil, cmd.Err
+	}
+
+	return cmd, nil
+}
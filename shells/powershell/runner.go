@@ -0,0 +1,50 @@
+package powershell
+
+import (
+	"sync"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Runner runs a command in place of spawning a process.
+type Runner func(cmd *exec.Cmd) (*exec.PsOutput, error)
+
+var testRunner struct {
+	sync.RWMutex
+	fn Runner
+}
+
+// Sets a runner that is called instead of spawning a process for
+// every Run and Output function in the package, including the
+// Options methods, so that code calling powershell can be unit tested.
+// The runner receives the fully constructed command, so tests can
+// assert on cmd.Path, cmd.Args, cmd.Dir and cmd.Env, and return
+// canned output. Call ResetTestRunner when the test completes.
+//
+// Example:
+//
+//	powershell.SetTestRunner(func(cmd *exec.Cmd) (*exec.PsOutput, error) {
+//		if !strings.Contains(cmd.Args[len(cmd.Args)-1], "Get-Service") {
+//			t.Errorf("unexpected args %v", cmd.Args)
+//		}
+//		return &exec.PsOutput{Stdout: []byte("ok\n")}, nil
+//	})
+//	defer powershell.ResetTestRunner()
+func SetTestRunner(fn Runner) {
+	testRunner.Lock()
+	defer testRunner.Unlock()
+	testRunner.fn = fn
+}
+
+// Removes the runner set with SetTestRunner so that commands
+// spawn processes again
+func ResetTestRunner() {
+	SetTestRunner(nil)
+}
+
+// returns the test runner or nil when none is set
+func runner() func(cmd *exec.Cmd) (*exec.PsOutput, error) {
+	testRunner.RLock()
+	defer testRunner.RUnlock()
+	return testRunner.fn
+}
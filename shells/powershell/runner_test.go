@@ -0,0 +1,40 @@
+package powershell
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+func TestSetTestRunner(t *testing.T) {
+	var got *exec.Cmd
+	SetTestRunner(func(cmd *exec.Cmd) (*exec.PsOutput, error) {
+		got = cmd
+		return &exec.PsOutput{Code: 3, Stdout: []byte("ok\r\n")}, nil
+	})
+	defer ResetTestRunner()
+
+	out, err := Output("Get-Date")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Code != 3 || string(out.Stdout) != "ok\n" {
+		t.Errorf("out = %+v, want the canned output normalized", out)
+	}
+
+	if got == nil || !slices.Contains(got.Args, "-Command") {
+		t.Fatalf("runner got %v, want the -Command invocation", got)
+	}
+
+	ResetTestRunner()
+	got = nil
+	if Which() == "" {
+		return
+	}
+
+	if _, err := Output("exit 0"); err != nil || got != nil {
+		t.Errorf("the runner was called after ResetTestRunner, err = %v", err)
+	}
+}
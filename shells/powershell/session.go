@@ -0,0 +1,85 @@
+package powershell
+
+import (
+	"encoding/base64"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+	"github.com/jolt9dev/go-spawn/internal/session"
+)
+
+// Session is a long-lived powershell process that runs scripts one
+// after another, which avoids the cost of starting a process per
+// script, which is considerable for powershell. State such as
+// variables, functions, imported modules and the location is kept
+// between scripts. Create it with NewSession and Close it when done.
+type Session struct {
+	s *session.Session
+}
+
+// Starts a new powershell session that reads scripts with
+// -Command -. Each script is dot sourced from a script block so
+// that parse errors and terminating errors fail the script rather
+// than the session. A script fails with the last native exit code
+// or 1 when it threw. StopOnError and the other options that
+// modify inline scripts are not applied, set
+// $ErrorActionPreference in the first script instead. Calling exit
+// ends the session.
+//
+// Example:
+//
+//	s, err := powershell.NewSession()
+//	if err != nil {
+//		return err
+//	}
+//	defer s.Close()
+//
+//	s.Send("Import-Module ./build.psm1")
+//	out, err := s.Send("Invoke-Build -Task test")
+func NewSession() (*Session, error) {
+	return NewOptions().NewSession()
+}
+
+// Starts a new powershell session using the options
+func (o *Options) NewSession() (*Session, error) {
+	s, err := session.Start(o.command("-Command", "-"), func(script, marker string) string {
+		// -Command - runs each line as it is read, so the script is
+		// passed on a single line
+		encoded := base64.StdEncoding.EncodeToString([]byte(script))
+		return "$global:LASTEXITCODE = 0; $__ok = $true; " +
+			"try { . ([scriptblock]::Create([Text.Encoding]::UTF8.GetString([Convert]::FromBase64String('" + encoded + "')))) | Out-Default } " +
+			"catch { $__ok = $false; [Console]::Error.WriteLine($_.ToString()) }; " +
+			"$__ec = if ($LASTEXITCODE) { $LASTEXITCODE } elseif (-not $__ok) { 1 } else { 0 }; " +
+			"[Console]::Out.WriteLine(); [Console]::Out.WriteLine('" + marker + " ' + $__ec); [Console]::Error.WriteLine('" + marker + "')"
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{s: s}, nil
+}
+
+// Runs the script in the session and returns stdout with surrounding
+// whitespace trimmed. When the script fails, an error is returned
+// that includes the exit code and stderr.
+func (s *Session) Send(script string) (string, error) {
+	out, err := s.SendOutput(script)
+	if err != nil {
+		return "", err
+	}
+
+	return proc.Text("powershell script", out, nil)
+}
+
+// Runs the script in the session and returns the captured stdout,
+// stderr and exit code.
+func (s *Session) SendOutput(script string) (*exec.PsOutput, error) {
+	stdout, stderr, code, err := s.s.Send(script)
+	return &exec.PsOutput{Stdout: []byte(stdout), Stderr: []byte(stderr), Code: code}, err
+}
+
+// Ends the session and waits for powershell to exit
+func (s *Session) Close() error {
+	return s.s.Close()
+}
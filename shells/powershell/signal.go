@@ -0,0 +1,28 @@
+package powershell
+
+// The default of Options.ForwardSignals.
+var ForwardSignals = false
+
+// Sets whether the interrupt and terminate signals received by the
+// current process are forwarded to the command. When true, the
+// command runs in its own process group and a signal such as the
+// SIGINT of Ctrl-C is relayed to the whole group, so the script and
+// its children are aborted together instead of being orphaned, and
+// the current process keeps running to collect the exit code. On
+// Windows, CTRL_BREAK_EVENT is sent to the process group instead.
+// When stdin is a terminal, the command stays in the foreground
+// process group, which receives Ctrl-C from the terminal, and only
+// the other signals are relayed.
+// To stop the command without a signal, cancel the context passed
+// to RunContext.
+//
+// Example:
+//
+//	out, err := powershell.NewOptions().WithForwardSignals(true).Run("./long-build.ps1")
+//	if out.Code != 0 {
+//		log.Printf("build aborted with code %d", out.Code)
+//	}
+func (o *Options) WithForwardSignals(forward bool) *Options {
+	o.ForwardSignals = forward
+	return o
+}
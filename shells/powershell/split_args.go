@@ -0,0 +1,26 @@
+package powershell
+
+import "github.com/jolt9dev/go-spawn/internal/psscript"
+
+var (
+	ErrUnterminatedQuote = psscript.ErrUnterminatedQuote
+	ErrTrailingEscape    = psscript.ErrTrailingEscape
+)
+
+// Splits the string into arguments using powershell quoting rules
+// instead of the POSIX rules of exec.SplitArgs. Single quotes are
+// literal and a doubled single quote stands for one, double quotes
+// allow a doubled double quote for one and the backtick escapes the
+// next character both inside double quotes and outside of quotes,
+// e.g. `n for a newline. Backslashes are literal, so windows paths
+// need no escaping. Quotes of the other kind and variables such as
+// $env:PATH are kept verbatim, nothing is evaluated. An error is
+// returned for unterminated quotes and a trailing backtick.
+//
+// Example:
+//
+//	powershell.SplitArgs(`-Command "Get-Item 'a b'"`) // ["-Command", "Get-Item 'a b'"]
+//	powershell.SplitArgs(`-File C:\scripts\build.ps1`) // ["-File", "C:\\scripts\\build.ps1"]
+func SplitArgs(s string) ([]string, error) {
+	return psscript.SplitArgs(s)
+}
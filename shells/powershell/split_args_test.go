@@ -0,0 +1,53 @@
+package powershell
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+		err  error
+	}{
+		{"empty", "", []string{}, nil},
+		{"spaces", "  a \t b\r\n c ", []string{"a", "b", "c"}, nil},
+		{"double quotes", `-Command "Get-Item 'a b'"`, []string{"-Command", "Get-Item 'a b'"}, nil},
+		{"single quotes", `'a "b" c'`, []string{`a "b" c`}, nil},
+		{"doubled single quote", `'it''s'`, []string{"it's"}, nil},
+		{"doubled double quote", `"say ""hi"""`, []string{`say "hi"`}, nil},
+		{"windows path", `-File C:\scripts\build.ps1`, []string{"-File", `C:\scripts\build.ps1`}, nil},
+		{"backtick escapes", "a`tb \"c`nd\"", []string{"a\tb", "c\nd"}, nil},
+		{"backtick space", "a` b", []string{"a b"}, nil},
+		{"backtick quote", "`\"a`'", []string{`"a'`}, nil},
+		{"backtick in single quotes", "'a`nb'", []string{"a`nb"}, nil},
+		{"variables verbatim", `$env:PATH "$x"`, []string{"$env:PATH", "$x"}, nil},
+		{"adjacent quotes", `a'b c'"d"`, []string{"ab cd"}, nil},
+		{"empty quotes", `'' ""`, []string{"", ""}, nil},
+		{"unterminated single quote", `'a`, nil, ErrUnterminatedQuote},
+		{"unterminated double quote", `"a`, nil, ErrUnterminatedQuote},
+		{"trailing backtick", "a`", nil, ErrTrailingEscape},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitArgs(tt.in)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandSplitError(t *testing.T) {
+	if err := Command(`-Command 'a`).Err; !errors.Is(err, ErrUnterminatedQuote) {
+		t.Errorf("err = %v, want ErrUnterminatedQuote", err)
+	}
+}
@@ -0,0 +1,34 @@
+package powershell
+
+import (
+	"context"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// Outputs a new powershell inline script or file and invokes onLine
+// for each line as it is written to stdout or stderr. The stream
+// is either "stdout" or "stderr". Lines are delivered in order
+// for each stream and onLine is called from a single goroutine,
+// so it does not need to be safe for concurrent use. The captured
+// output is still returned once the process exits.
+//
+// Example:
+//
+//	out, err := powershell.OutputStream("dotnet build", func(stream, line string) {
+//		log.Printf("[%s] %s", stream, line)
+//	})
+func OutputStream(script string, onLine func(stream string, line string)) (*exec.PsOutput, error) {
+	return NewOptions().OutputStream(script, onLine)
+}
+
+// Outputs the inline script or file and invokes onLine for each
+// line written to stdout or stderr
+func (o *Options) OutputStream(script string, onLine func(stream string, line string)) (*exec.PsOutput, error) {
+	cmd := o.runScript(script)
+	ctx, cancel := o.context(context.Background())
+	defer cancel()
+	out, err := proc.Stream(ctx, cmd, onLine)
+	return out, o.finish(cmd, err)
+}
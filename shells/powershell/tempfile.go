@@ -0,0 +1,72 @@
+package powershell
+
+import (
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/tempfile"
+)
+
+var tempFiles tempfile.Files
+
+// When true, the temp file written for an inline script is kept on
+// disk when the command fails so that the failure can be reproduced.
+// The path of the temp file is included in the error regardless.
+var KeepTempOnError = false
+
+// The directory temp scripts are written to. When empty, the
+// default temp directory of the os is used. The directory must
+// exist and be writable, otherwise the error is returned when the
+// command is run. Scripts are passed to powershell rather than executed
+// directly, so a directory mounted with noexec works.
+var TempDir = ""
+
+// Creates a new powershell command that writes the inline script
+// to a temp .ps1 file and executes it with -File, which preserves
+// the exact whitespace and quoting of the script. The file is
+// written with CRLF line endings on Windows and LF elsewhere. The
+// temp file is removed by Run and Output after the command
+// completes, even when it fails. When running the command
+// directly, call Cleanup after it completes.
+//
+// Example:
+//
+//	cmd := powershell.ScriptFile(`$name = "world"
+//	Write-Host @"
+//	  Hello "$name" 'quoted'
+//	"@`)
+//	defer powershell.Cleanup(cmd)
+//	cmd.Output()
+func ScriptFile(script string) *exec.Cmd {
+	return NewOptions().ScriptFile(script)
+}
+
+// Creates a new powershell command that writes the inline script
+// to a temp .ps1 file and executes it with -File.
+func (o *Options) ScriptFile(script string) *exec.Cmd {
+	return o.shared().ScriptFile(host, script)
+}
+
+// Sets the directory temp scripts are written to, e.g. a project
+// local .tmp directory
+func (o *Options) WithTempDir(dir string) *Options {
+	o.TempDir = dir
+	return o
+}
+
+// Sets whether the temp file written for an inline script is kept
+// on disk when the command fails
+func (o *Options) WithKeepTempOnError(keep bool) *Options {
+	o.KeepTempOnError = keep
+	return o
+}
+
+// removes the temp file of a completed command and wraps the error
+// with the path of the temp file
+func (o *Options) finish(cmd *exec.Cmd, err error) error {
+	return o.shared().Finish(host, cmd, err)
+}
+
+// Removes the temp file created for the command by ScriptFile,
+// if any. It is safe to call for any command.
+func Cleanup(cmd *exec.Cmd) error {
+	return tempFiles.Remove(cmd)
+}
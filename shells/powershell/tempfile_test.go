@@ -0,0 +1,92 @@
+package powershell
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const hereStringScript = "$name = 'world'\nWrite-Output @\"\n  Hello \"$name\" 'quoted'\n\"@"
+
+func TestScriptHereStringLeavesNoFile(t *testing.T) {
+	dir := t.TempDir()
+	cmd := NewOptions().WithTempDir(dir).Script(hereStringScript)
+
+	i := slices.Index(cmd.Args, "-EncodedCommand")
+	if i < 0 || i+1 >= len(cmd.Args) {
+		t.Fatalf("args = %q, want -EncodedCommand", cmd.Args)
+	}
+
+	script, err := DecodeCommand(cmd.Args[i+1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(script, hereStringScript) {
+		t.Errorf("decoded script = %q, want it to contain %q", script, hereStringScript)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("Script wrote %d files to the temp dir", len(entries))
+	}
+}
+
+func TestRunScriptHereStringWritesFile(t *testing.T) {
+	dir := t.TempDir()
+	cmd := NewOptions().WithTempDir(dir).runScript(hereStringScript)
+
+	i := slices.Index(cmd.Args, "-File")
+	if i < 0 || i+1 >= len(cmd.Args) {
+		t.Fatalf("args = %q, want -File", cmd.Args)
+	}
+
+	file := cmd.Args[i+1]
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(string(data), "\ufeff") {
+		t.Errorf("temp file %s does not start with a UTF-8 byte order mark", file)
+	}
+
+	if err := Cleanup(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("temp file %s still exists after Cleanup", file)
+	}
+}
+
+func TestOutputHereString(t *testing.T) {
+	if Which() == "" {
+		t.Skip("powershell not found")
+	}
+
+	dir := t.TempDir()
+	out, err := NewOptions().WithTempDir(dir).Output(hereStringScript)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "  Hello \"world\" 'quoted'"
+	if got := strings.TrimRight(string(out.Stdout), "\r\n"); got != want {
+		t.Errorf("stdout = %q, want %q, stderr = %q", got, want, out.Stderr)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("Output left %d files in the temp dir", len(entries))
+	}
+}
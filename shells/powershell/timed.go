@@ -0,0 +1,53 @@
+package powershell
+
+import (
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Runs a new powershell inline script or file the same as Run and returns
+// how long the process ran. The duration is measured from the start
+// of the process until it exited, excluding the creation of the
+// command and temp files, and matches the StartedAt and EndedAt of
+// the output. It is zero when the process did not start.
+//
+// Example:
+//
+//	_, took, err := powershell.RunTimed("./build.ps1")
+//	log.Printf("build took %s", took)
+func RunTimed(script string) (*exec.PsOutput, time.Duration, error) {
+	return NewOptions().RunTimed(script)
+}
+
+// Outputs a new powershell inline script or file the same as Output and
+// returns how long the process ran, see RunTimed.
+//
+// Example:
+//
+//	out, took, err := powershell.OutputTimed("Get-ChildItem -Recurse | Measure-Object")
+//	log.Printf("counted in %s: %s", took, out.Stdout)
+func OutputTimed(script string) (*exec.PsOutput, time.Duration, error) {
+	return NewOptions().OutputTimed(script)
+}
+
+// Runs the inline script or file and returns how long it ran
+func (o *Options) RunTimed(script string) (*exec.PsOutput, time.Duration, error) {
+	out, err := o.Run(script)
+	return out, duration(out), err
+}
+
+// Outputs the inline script or file and returns how long it ran
+func (o *Options) OutputTimed(script string) (*exec.PsOutput, time.Duration, error) {
+	out, err := o.Output(script)
+	return out, duration(out), err
+}
+
+// returns the time the process of the output ran
+func duration(out *exec.PsOutput) time.Duration {
+	if out == nil || out.StartedAt.IsZero() || out.EndedAt.Before(out.StartedAt) {
+		return 0
+	}
+
+	return out.EndedAt.Sub(out.StartedAt)
+}
@@ -0,0 +1,53 @@
+package powershell
+
+import (
+	"fmt"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jolt9dev/go-spawn/internal/version"
+)
+
+var versions version.Cache
+
+// Returns the version of the resolved powershell such as 7.4.1.
+// pwsh is asked with pwsh --version while Windows PowerShell,
+// which does not support the flag, is asked for
+// $PSVersionTable.PSVersion. The version is cached per
+// executable for the lifetime of the process. When powershell
+// cannot be found, an *ErrShellNotFound error is returned.
+//
+// Example:
+//
+//	v, err := powershell.Version()
+//	if err == nil && strings.HasPrefix(v, "5.") {
+//		log.Println("running on Windows PowerShell")
+//	}
+func Version() (string, error) {
+	exe, err := WhichE()
+	if err != nil {
+		return "", err
+	}
+
+	return versions.Get(exe, func() (string, error) {
+		args := []string{"--version"}
+		name := strings.TrimSuffix(strings.ToLower(filepath.Base(exe)), ".exe")
+		if name != "pwsh" {
+			args = []string{"-NoProfile", "-NonInteractive", "-Command", "$PSVersionTable.PSVersion.ToString()"}
+		}
+
+		out, err := osexec.Command(exe, args...).Output()
+		if err != nil {
+			return "", fmt.Errorf("powershell: unable to get the version of %s: %w", exe, err)
+		}
+
+		text := strings.TrimSpace(string(out))
+		v, ok := version.Parse(text)
+		if !ok {
+			return "", fmt.Errorf("powershell: unable to parse the version from %q", text)
+		}
+
+		return v, nil
+	})
+}
@@ -0,0 +1,27 @@
+package powershell
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWhichCoreQuotedPathWithSpaces(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Program Files", "PowerShell", "7")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	exe := filepath.Join(dir, "pwsh")
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PWSH_PATH", `"`+exe+`"`)
+	ResetWhichCache()
+	t.Cleanup(ResetWhichCache)
+
+	if got := WhichCore(); got != exe {
+		t.Errorf("WhichCore() = %q, want %q", got, exe)
+	}
+}
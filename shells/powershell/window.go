@@ -0,0 +1,23 @@
+package powershell
+
+// Creates new options from the package level defaults that start
+// powershell with the given -WindowStyle, one of Normal, Minimized,
+// Maximized or Hidden. With Hidden, the process is also started
+// without a console window, so desktop applications that embed the
+// library do not flash a window. Such a process does not share the
+// console, so RunTimeout kills it right away instead of sending
+// CTRL_BREAK_EVENT first. The style is ignored on platforms other
+// than Windows.
+//
+// Example:
+//
+//	powershell.WithWindowStyle("Hidden").Output("Get-Printer | Select-Object Name")
+func WithWindowStyle(style string) *Options {
+	return NewOptions().WithWindowStyle(style)
+}
+
+// Sets the -WindowStyle powershell starts with on Windows
+func (o *Options) WithWindowStyle(style string) *Options {
+	o.WindowStyle = style
+	return o
+}
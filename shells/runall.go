@@ -0,0 +1,62 @@
+package shells
+
+import (
+	"fmt"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Result is the outcome of running a script under one shell
+// with RunAll.
+type Result struct {
+	// The captured output, nil when the shell was skipped.
+	Output *exec.PsOutput
+
+	// The error returned by the shell, or the reason the shell
+	// was skipped.
+	Err error
+
+	// True when the shell is not registered or not installed.
+	Skipped bool
+}
+
+// Outputs the inline script under each of the named shells, or every
+// registered shell when no names are given, and returns the result
+// of each shell by name. Shells that are not registered or not
+// installed are skipped and marked in their result. The shells run
+// one after another so that their output does not interleave.
+//
+// Example:
+//
+//	results := shells.RunAll("./install.sh --dry-run", "sh", "bash", "zsh")
+//	for name, r := range results {
+//		if r.Skipped {
+//			continue
+//		}
+//
+//		fmt.Println(name, r.Output.Code)
+//	}
+func RunAll(script string, names ...string) map[string]*Result {
+	if len(names) == 0 {
+		names = Names()
+	}
+
+	results := make(map[string]*Result, len(names))
+	for _, name := range names {
+		shell, ok := Get(name)
+		if !ok {
+			results[name] = &Result{Skipped: true, Err: fmt.Errorf("shell %s is not registered", name)}
+			continue
+		}
+
+		if shell.Which() == "" {
+			results[name] = &Result{Skipped: true, Err: fmt.Errorf("shell %s is not installed", name)}
+			continue
+		}
+
+		out, err := shell.Output(script)
+		results[name] = &Result{Output: out, Err: err}
+	}
+
+	return results
+}
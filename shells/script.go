@@ -0,0 +1,113 @@
+package shells
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+// the shell used for a script file without a shebang by extension
+var extensions = map[string]string{
+	".sh":   "sh",
+	".bash": "bash",
+	".zsh":  "zsh",
+	".ksh":  "ksh",
+	".dash": "dash",
+	".fish": "fish",
+	".ps1":  "powershell",
+	".cmd":  "cmd",
+	".bat":  "cmd",
+}
+
+// Runs the script file with the interpreter named by its shebang,
+// e.g. #!/usr/bin/env bash, falling back to the extension of the
+// file such as .sh, .ps1 or .cmd when it has none. Stdout, stderr
+// and stdin are inherited from the current process. Interpreters
+// that are registered shells run through the shell package, with
+// its default flags unless the shebang lists its own arguments, so
+// the shell package must be imported, e.g. with shells/all. Other
+// interpreters such as python3 run as named when they are found on
+// the PATH. An error is returned when the interpreter cannot be
+// determined or is not found.
+//
+// Example:
+//
+//	for _, f := range hooks {
+//		if _, err := shells.RunScriptFile(f); err != nil {
+//			return fmt.Errorf("hook %s: %w", f, err)
+//		}
+//	}
+func RunScriptFile(file string) (*exec.PsOutput, error) {
+	cmd, err := scriptFileCommand(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return proc.Run(context.Background(), cmd)
+}
+
+// creates the command that runs the script file with its interpreter
+func scriptFileCommand(file string) (*exec.Cmd, error) {
+	exe, args := resolveInterpreter(file)
+	if exe == "" {
+		return nil, fmt.Errorf("unable to determine the interpreter for %s, add a shebang", file)
+	}
+
+	name := strings.TrimSuffix(strings.ToLower(filepath.Base(exe)), ".exe")
+	if shell, ok := Get(name); ok {
+		if shell.Which() == "" {
+			return nil, fmt.Errorf("shell %s for %s is not installed", name, file)
+		}
+
+		if len(args) == 0 {
+			return shell.File(file), nil
+		}
+
+		return shell.New(append(args, file)...), nil
+	}
+
+	path, err := osexec.LookPath(exe)
+	if err != nil {
+		return nil, fmt.Errorf("interpreter %s for %s not found: %w", exe, file, err)
+	}
+
+	return exec.New(path, append(args, file)...), nil
+}
+
+// returns the interpreter and its arguments from the shebang of
+// the file, skipping /usr/bin/env and its flags, or the shell for
+// the extension of the file when there is no shebang. Returns an
+// empty string when neither names an interpreter.
+func resolveInterpreter(file string) (exe string, args []string) {
+	if f, err := os.Open(file); err == nil {
+		line, _ := bufio.NewReader(f).ReadString('\n')
+		f.Close()
+		line = strings.TrimPrefix(line, "\ufeff")
+		if strings.HasPrefix(line, "#!") {
+			fields := strings.Fields(line[2:])
+			if len(fields) > 0 && filepath.Base(fields[0]) == "env" {
+				fields = fields[1:]
+				for len(fields) > 0 && strings.HasPrefix(fields[0], "-") {
+					fields = fields[1:]
+				}
+			}
+
+			if len(fields) > 0 {
+				return fields[0], fields[1:]
+			}
+		}
+	}
+
+	if name, ok := extensions[strings.ToLower(filepath.Ext(file))]; ok {
+		return name, nil
+	}
+
+	return "", nil
+}
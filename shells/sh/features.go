@@ -0,0 +1,37 @@
+package sh
+
+import (
+	"github.com/jolt9dev/go-spawn/internal/busybox"
+	"github.com/jolt9dev/go-spawn/internal/probe"
+	"github.com/jolt9dev/go-spawn/shells"
+)
+
+// Returns the features of the resolved sh. POSIX sh supports -e and
+// -u, while pipefail depends on the implementation, e.g. dash only
+// supports it since 0.5.12, and is probed once per executable.
+// Process substitution and arrays are not part of POSIX sh and are
+// reported as unsupported even when sh is bash. The zero value is
+// returned when sh is not installed.
+//
+// Example:
+//
+//	if sh.Features().PipeFail {
+//		sh.New("-e", "-o", "pipefail", "-c", "curl -fsSL $URL | tar -xz").Run()
+//	}
+func Features() shells.ShellFeatures {
+	exe := Which()
+	if exe == "" {
+		return shells.ShellFeatures{}
+	}
+
+	args := []string{"-c", "set -o pipefail"}
+	if busybox.IsName(exe) {
+		args = append([]string{"sh"}, args...)
+	}
+
+	return shells.ShellFeatures{
+		ErrExit:  true,
+		PipeFail: probe.Succeeds(exe, args...),
+		NoUnset:  true,
+	}
+}
@@ -0,0 +1,201 @@
+package sh
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/busybox"
+	"github.com/jolt9dev/go-spawn/internal/lookup"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+func init() {
+	exec.Register("sh", &exec.Executable{
+		Name:     "sh",
+		Variable: "SH_PATH",
+		Windows: []string{
+			"${ProgramFiles}\\Git\\bin\\sh.exe",
+			"${ProgramFiles}\\Git\\usr\\bin\\sh.exe",
+			"${ProgramFiles(x86)}\\Git\\bin\\sh.exe",
+			"${ProgramFiles(x86)}\\Git\\usr\\bin\\sh.exe",
+		},
+		Linux: []string{
+			"/bin/sh",
+			"/usr/bin/sh",
+		},
+	})
+
+	exec.Register("busybox", &exec.Executable{
+		Name:     "busybox",
+		Variable: "BUSYBOX_PATH",
+		Linux: []string{
+			"/bin/busybox",
+			"/usr/bin/busybox",
+		},
+	})
+}
+
+// Returns the path to the sh executable or an empty string.
+// When sh is not found, the path to busybox is returned
+// which runs sh as an applet. The path is resolved in order from
+// SH_PATH and BUSYBOX_PATH, which may be quoted and contain
+// variables such as ${HOME}, then the known locations and then
+// the PATH.
+func Which() string {
+	if exe := WhichFromEnv(); exe != "" {
+		return exe
+	}
+
+	exe, _ := exec.Find("sh")
+	if exe == "" {
+		exe, _ = exec.Find("busybox")
+	}
+
+	return exe
+}
+
+// Returns the unquoted and expanded path from SH_PATH, or from
+// BUSYBOX_PATH when SH_PATH is not set, when it is an existing
+// file, otherwise an empty string.
+//
+// Example:
+//
+//	if sh.WhichFromEnv() == "" {
+//		log.Println("SH_PATH is not set, probing for sh")
+//	}
+func WhichFromEnv() string {
+	if exe := lookup.FromEnv("SH_PATH"); exe != "" {
+		return exe
+	}
+
+	return lookup.FromEnv("BUSYBOX_PATH")
+}
+
+// Returns the path to the sh executable or the default
+// which is the name of the executable without a path or
+// extension.
+func WhichOrDefault() string {
+	exe := Which()
+	if exe == "" {
+		return "sh"
+	}
+
+	return exe
+}
+
+// Returns the locations probed for sh, followed by busybox, on the current os
+// in order with environment variables expanded, starting with
+// SH_PATH and BUSYBOX_PATH when set. PATH is searched when none of them exist.
+//
+// Example:
+//
+//	fmt.Println("searched:", strings.Join(sh.Candidates(), ", "))
+func Candidates() []string {
+	return lookup.Candidates("sh", "busybox")
+}
+
+// Creates a new sh command with the given arguments
+// using vardiac arguments
+//
+// Example:
+//
+//	sh.New("-e", "-c", "echo hello").Run()
+func New(args ...string) *exec.Cmd {
+	exe := WhichOrDefault()
+	// busybox only runs the sh applet when it is the first argument,
+	// a symlink named sh already selects it
+	if busybox.IsName(exe) {
+		args = append([]string{"sh"}, args...)
+	}
+
+	return exec.New(exe, args...)
+}
+
+// Creates a new sh command with the given arguments
+// using a single string
+//
+// Example:
+//
+//	sh.Command("-e -c 'echo hello'").Run()
+func Command(args string) *exec.Cmd {
+	return New(exec.SplitArgs(args)...)
+}
+
+// Creates a new sh command with the given script file
+//
+// Example:
+//
+//	sh.File("script.sh").Run()
+func File(file string) *exec.Cmd {
+	return New("-e", file)
+}
+
+// Creates a new sh command with the given inline script
+// or file. However, the file must have a .sh extension
+// and be on a single line. POSIX sh does not support
+// pipefail, so only -e is set.
+//
+// Example:
+//
+//	sh.Script(`apk add --no-cache \
+//	  curl \
+//	  zip`).WithCwd("/path/to/dir").Run()
+//	sh.Script("/path/to/script.sh").Output()
+func Script(script string) *exec.Cmd {
+	if !strings.ContainsAny(script, "\n") {
+		script = strings.TrimSpace(script)
+
+		if strings.HasSuffix(script, ".sh") {
+			return File(script)
+		}
+	}
+
+	return New("-e", "-c", script)
+}
+
+// Run a new sh inline script or file.
+// When using a file, the file must have a .sh extension
+// and be on a single line.
+// Run will set stdout and stderr to inherit and not
+// capture the output.
+//
+// Example:
+//
+//	sh.Run(`apk add --no-cache \
+//	  curl \
+//	  zip`)
+//	sh.Run("/path/to/script.sh")
+func Run(script string) (*exec.PsOutput, error) {
+	return proc.Run(context.Background(), Script(script))
+}
+
+// Output a new sh inline script or file.
+// When using a file, the file must have a .sh extension
+// and be on a single line.
+// Output will set stdout and stderr to piped and captures
+// the standard output and error streams
+//
+// Example:
+//
+//	out, err := sh.Output("/path/to/script.sh")
+//	if err != nil || out.Code != 0 {
+//	// handle error
+//	}
+func Output(script string) (*exec.PsOutput, error) {
+	return proc.Output(context.Background(), Script(script))
+}
+
+// Reports whether the resolved sh is busybox ash, either the
+// busybox executable itself or sh linked to it such as /bin/sh
+// on Alpine. busybox ash supports -e but not bash style options
+// such as -o pipefail. The result is cached per executable.
+//
+// Example:
+//
+//	if sh.IsBusybox() {
+//		log.Println("running on busybox, avoid bash-isms")
+//	}
+func IsBusybox() bool {
+	return busybox.Is(Which())
+}
@@ -0,0 +1,135 @@
+// Package shells defines the Shell interface implemented by each
+// shell package so that applications can select a shell at runtime,
+// e.g. from configuration. Shell packages register themselves when
+// imported, import shells/all to register every shell.
+//
+// Example:
+//
+//	import (
+//		"github.com/jolt9dev/go-spawn/shells"
+//		_ "github.com/jolt9dev/go-spawn/shells/all"
+//	)
+//
+//	sh, ok := shells.Get(cfg.Shell)
+//	if !ok {
+//		return fmt.Errorf("unknown shell %s", cfg.Shell)
+//	}
+//
+//	out, err := sh.Output("echo hello")
+package shells
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+// Shell is implemented by each shell package and mirrors the
+// functions the packages expose.
+type Shell interface {
+	// Returns the name the shell is registered with
+	Name() string
+
+	// Returns the path to the shell executable or an empty string
+	Which() string
+
+	// Creates a new command with the given arguments
+	New(args ...string) *exec.Cmd
+
+	// Creates a new command with the arguments in a single string
+	Command(args string) *exec.Cmd
+
+	// Creates a new command that runs the script file
+	File(file string) *exec.Cmd
+
+	// Creates a new command that runs the inline script or file
+	Script(script string) *exec.Cmd
+
+	// Runs the inline script or file with inherited stdio
+	Run(script string) (*exec.PsOutput, error)
+
+	// Runs the inline script or file and captures the output
+	Output(script string) (*exec.PsOutput, error)
+}
+
+// Session is a long-lived shell process that runs scripts one after
+// another and keeps state such as variables between them.
+type Session interface {
+	// Runs the script and returns its trimmed stdout or an error
+	// when it failed
+	Send(script string) (string, error)
+
+	// Ends the session and waits for the shell to exit
+	Close() error
+}
+
+// SessionShell is implemented by the shells that support sessions,
+// such as bash and powershell.
+type SessionShell interface {
+	Shell
+
+	// Starts a new session
+	NewSession() (Session, error)
+}
+
+var registry = struct {
+	sync.RWMutex
+	data map[string]Shell
+}{data: map[string]Shell{}}
+
+// Registers the shell with the given name. Shell packages call
+// Register from init, registering a name again replaces the shell.
+func Register(name string, shell Shell) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.data[name] = shell
+}
+
+// Returns the shell registered with the given name
+func Get(name string) (Shell, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	shell, ok := registry.data[name]
+	return shell, ok
+}
+
+// Starts a new session for the shell registered with the given
+// name. An error is returned when the shell is not registered or
+// does not support sessions.
+//
+// Example:
+//
+//	s, err := shells.NewSession("bash")
+//	if err != nil {
+//		return err
+//	}
+//	defer s.Close()
+//	out, err := s.Send("echo hello")
+func NewSession(name string) (Session, error) {
+	shell, ok := Get(name)
+	if !ok {
+		return nil, fmt.Errorf("shell %s is not registered", name)
+	}
+
+	ss, ok := shell.(SessionShell)
+	if !ok {
+		return nil, fmt.Errorf("shell %s does not support sessions", name)
+	}
+
+	return ss.NewSession()
+}
+
+// Returns the sorted names of the registered shells
+func Names() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.data))
+	for name := range registry.data {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
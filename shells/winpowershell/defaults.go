@@ -0,0 +1,91 @@
+package winpowershell
+
+import (
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+	"github.com/jolt9dev/go-spawn/shells/powershell"
+)
+
+// CommandDefaults holds the values applied to every command, see
+// powershell.CommandDefaults.
+type CommandDefaults = powershell.CommandDefaults
+
+// The defaults applied to every Windows PowerShell command created
+// by the package. They are separate from powershell.Defaults. Set
+// the fields once at startup, before commands are created from
+// other goroutines.
+//
+// Example:
+//
+//	winpowershell.Defaults.Cwd = "C:\\app"
+//	winpowershell.Defaults.Timeout = 5 * time.Minute
+var Defaults CommandDefaults
+
+// Called with each command right before Run and Output start the
+// process, after every option has been applied. It is not called
+// for commands that are run directly with the methods of exec.Cmd.
+// Set it once at startup.
+//
+// Example:
+//
+//	winpowershell.BeforeRun = func(cmd *exec.Cmd) {
+//		log.Println("running", cmd.Args)
+//	}
+var BeforeRun func(cmd *exec.Cmd)
+
+// Called with the executable, arguments and working directory of
+// each command right before Run and Output start the process, after
+// BeforeRun. The arguments contain inline scripts verbatim, so
+// callers are responsible for redacting secrets. Set it once at
+// startup.
+//
+// Example:
+//
+//	winpowershell.AuditLog = func(exe string, args []string, cwd string) {
+//		log.Printf("audit: %s %q in %s", exe, args, cwd)
+//	}
+var AuditLog func(exe string, args []string, cwd string)
+
+// returns a copy of the default environment variables
+func defaultEnv() map[string]string {
+	if len(Defaults.Env) == 0 {
+		return nil
+	}
+
+	env := make(map[string]string, len(Defaults.Env))
+	for k, v := range Defaults.Env {
+		env[k] = v
+	}
+
+	return env
+}
+
+// applies the default working directory and environment to a
+// command created with New
+func applyDefaults(cmd *exec.Cmd) *exec.Cmd {
+	cmd.Dir = Defaults.Cwd
+	if env := defaultEnv(); env != nil {
+		cmd.Env = proc.MergeEnv(env, false)
+	}
+
+	return cmd
+}
+
+// returns the func called right before a command starts which
+// calls BeforeRun and AuditLog when set
+func beforeRun() func(cmd *exec.Cmd) {
+	before, audit := BeforeRun, AuditLog
+	if before == nil && audit == nil {
+		return nil
+	}
+
+	return func(cmd *exec.Cmd) {
+		if before != nil {
+			before(cmd)
+		}
+
+		if audit != nil {
+			audit(cmd.Path, append([]string{}, cmd.Args[1:]...), proc.Cwd(cmd))
+		}
+	}
+}
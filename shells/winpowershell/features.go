@@ -0,0 +1,19 @@
+package winpowershell
+
+import "github.com/jolt9dev/go-spawn/shells"
+
+// Returns the features of Windows PowerShell, which has arrays but
+// none of the POSIX shell options. The zero value is returned when
+// powershell.exe is not installed.
+//
+// Example:
+//
+//	f := winpowershell.Features()
+//	log.Println("arrays:", f.Arrays)
+func Features() shells.ShellFeatures {
+	if Which() == "" {
+		return shells.ShellFeatures{}
+	}
+
+	return shells.ShellFeatures{Arrays: true}
+}
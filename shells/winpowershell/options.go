@@ -0,0 +1,303 @@
+package winpowershell
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+	"github.com/jolt9dev/go-spawn/internal/psscript"
+	"github.com/jolt9dev/go-spawn/internal/tempfile"
+)
+
+// When true, inline scripts are prefixed with
+// $ErrorActionPreference = 'Stop' so that cmdlet errors
+// terminate the script with a non-zero exit code.
+var StopOnError = true
+
+// The execution policy passed with -ExecutionPolicy so that script
+// files run on locked down hosts. Set to an empty string to use
+// the policy configured on the host.
+var ExecutionPolicy = "Bypass"
+
+// The COM apartment powershell.exe starts in, either STA or MTA.
+// Set to an empty string to use the default of the host, which is
+// MTA for Windows PowerShell 2.0 and STA since 3.0.
+var ApartmentState = "STA"
+
+// When true, captured output is normalized the same as
+// powershell.NormalizeOutput: UTF-16 output is decoded, a leading
+// byte order mark is removed and CRLF is replaced with LF.
+var NormalizeOutput = true
+
+// ErrApartmentState is returned when running a command created with
+// an apartment state other than STA or MTA.
+var ErrApartmentState = errors.New("winpowershell: the apartment state must be STA or MTA")
+
+var tempFiles tempfile.Files
+
+// Options controls how Windows PowerShell inline scripts and files
+// are invoked. Use NewOptions to create options initialized from
+// the package level defaults.
+type Options struct {
+	// Prepends $ErrorActionPreference = 'Stop' and appends an exit
+	// with $LASTEXITCODE to inline scripts.
+	StopOnError bool
+
+	// Exits inline scripts with 1 when any error was recorded in
+	// $Error, see powershell.Options.ErrorAsFailure.
+	ErrorAsFailure bool
+
+	// Passes inline scripts with characters that break command
+	// line quoting using -EncodedCommand.
+	UseEncodedCommand bool
+
+	// The value passed with -ExecutionPolicy. The flag is omitted
+	// when empty.
+	ExecutionPolicy string
+
+	// The COM apartment passed with -Sta or -Mta. The flag is
+	// omitted when empty.
+	ApartmentState string
+
+	// Loads the powershell profiles of the user and host, which
+	// are skipped with -NoProfile by default.
+	Profile bool
+
+	// The working directory of the command which must exist
+	// before the command is started. Inline scripts also start
+	// with Set-Location so that $PWD matches.
+	Dir string
+
+	// Environment variables merged onto the current process
+	// environment.
+	Env map[string]string
+
+	// When true, the current process environment is not
+	// inherited and only Env is passed to the command.
+	ClearEnv bool
+
+	// The encoding of the console output set at the start of
+	// inline scripts such as utf-8. When empty, the legacy code
+	// page of the host is used.
+	OutputEncoding string
+
+	// Decodes UTF-16 output, strips a leading byte order mark and
+	// normalizes CRLF line endings to LF in captured output.
+	NormalizeOutput bool
+
+	// The directory temp scripts are written to. When empty, the
+	// default temp directory of the os is used.
+	TempDir string
+
+	// Keeps the temp file written for an inline script on
+	// disk when the command fails.
+	KeepTempOnError bool
+
+	// The maximum duration of the command. The process tree is
+	// killed once exceeded. Zero or less means no limit.
+	Timeout time.Duration
+
+	// The maximum number of bytes captured per stream by Output.
+	// The process tree is killed once exceeded and the error wraps
+	// ErrOutputTruncated. Zero or less means no limit.
+	MaxOutputBytes int
+}
+
+// Creates new options initialized from the package
+// level defaults and Defaults
+func NewOptions() *Options {
+	return &Options{
+		StopOnError:     StopOnError,
+		ExecutionPolicy: ExecutionPolicy,
+		ApartmentState:  ApartmentState,
+		NormalizeOutput: NormalizeOutput,
+		Dir:             Defaults.Cwd,
+		Env:             defaultEnv(),
+		Timeout:         Defaults.Timeout,
+		MaxOutputBytes:  MaxOutputBytes,
+	}
+}
+
+// Sets whether inline scripts stop on the first error
+func (o *Options) WithStopOnError(stop bool) *Options {
+	o.StopOnError = stop
+	return o
+}
+
+// Sets whether inline scripts fail when any error was written
+// to the error stream
+func (o *Options) WithErrorAsFailure(fail bool) *Options {
+	o.ErrorAsFailure = fail
+	return o
+}
+
+// Sets whether inline scripts may be passed using
+// -EncodedCommand
+func (o *Options) WithEncodedCommand(encoded bool) *Options {
+	o.UseEncodedCommand = encoded
+	return o
+}
+
+// Sets the execution policy passed with -ExecutionPolicy
+func (o *Options) WithExecutionPolicy(policy string) *Options {
+	o.ExecutionPolicy = policy
+	return o
+}
+
+// Sets the COM apartment powershell.exe starts in, either STA
+// or MTA
+func (o *Options) WithApartmentState(state string) *Options {
+	o.ApartmentState = state
+	return o
+}
+
+// Sets whether the powershell profiles are loaded
+func (o *Options) WithProfile(load bool) *Options {
+	o.Profile = load
+	return o
+}
+
+// Sets the working directory of the command
+func (o *Options) WithDir(dir string) *Options {
+	o.Dir = dir
+	return o
+}
+
+// Sets environment variables that are merged onto the current
+// process environment, or replace it when ClearEnv is set
+func (o *Options) WithEnv(env map[string]string) *Options {
+	if o.Env == nil {
+		o.Env = map[string]string{}
+	}
+
+	for k, v := range env {
+		o.Env[k] = v
+	}
+
+	return o
+}
+
+// Sets whether the current process environment is excluded
+// from the command environment
+func (o *Options) WithClearEnv(clear bool) *Options {
+	o.ClearEnv = clear
+	return o
+}
+
+// Sets the encoding of the console output, e.g. utf-8, see
+// powershell.Options.WithOutputEncoding
+func (o *Options) WithOutputEncoding(name string) *Options {
+	o.OutputEncoding = name
+	return o
+}
+
+// Sets the directory temp scripts are written to
+func (o *Options) WithTempDir(dir string) *Options {
+	o.TempDir = dir
+	return o
+}
+
+// Sets whether the temp file written for an inline script is kept
+// on disk when the command fails
+func (o *Options) WithKeepTempOnError(keep bool) *Options {
+	o.KeepTempOnError = keep
+	return o
+}
+
+// Sets the maximum duration of the command. Zero or less
+// means no limit.
+func (o *Options) WithTimeout(timeout time.Duration) *Options {
+	o.Timeout = timeout
+	return o
+}
+
+// Creates a new Windows PowerShell command with the given script
+// file
+func (o *Options) File(file string) *exec.Cmd {
+	return o.shared().File(host, file)
+}
+
+// Creates a new Windows PowerShell command with the given inline
+// script or file. Scripts with here-strings are passed using
+// -EncodedCommand so that running the command directly leaves no
+// temp file behind.
+func (o *Options) Script(script string) *exec.Cmd {
+	return o.shared().Script(host, script, false)
+}
+
+// Creates a new Windows PowerShell command that writes the inline
+// script to a temp .ps1 file and executes it with -File. The temp
+// file is removed by Run and Output, call Cleanup when running the
+// command directly.
+func (o *Options) ScriptFile(script string) *exec.Cmd {
+	return o.shared().ScriptFile(host, script)
+}
+
+// Runs the inline script or file with stdout and stderr
+// inherited from the current process
+func (o *Options) Run(script string) (*exec.PsOutput, error) {
+	s := o.shared()
+	cmd := s.Script(host, script, true)
+	ctx, cancel := s.Context(host, context.Background())
+	defer cancel()
+	out, err := proc.Run(ctx, cmd)
+	return out, s.Finish(host, cmd, err)
+}
+
+// Runs the inline script or file and captures stdout
+// and stderr
+func (o *Options) Output(script string) (*exec.PsOutput, error) {
+	s := o.shared()
+	cmd := s.Script(host, script, true)
+	ctx, cancel := s.Context(host, context.Background())
+	defer cancel()
+	out, err := proc.Output(ctx, cmd)
+	return s.Normalize(out), s.Finish(host, cmd, err)
+}
+
+// Removes the temp file created for the command by ScriptFile,
+// if any. It is safe to call for any command.
+func Cleanup(cmd *exec.Cmd) error {
+	return tempFiles.Remove(cmd)
+}
+
+// returns the options shared with the powershell package
+func (o *Options) shared() *psscript.Options {
+	return &psscript.Options{
+		StopOnError:       o.StopOnError,
+		ErrorAsFailure:    o.ErrorAsFailure,
+		UseEncodedCommand: o.UseEncodedCommand,
+		ExecutionPolicy:   o.ExecutionPolicy,
+		ApartmentState:    o.ApartmentState,
+		OutputEncoding:    o.OutputEncoding,
+		NormalizeOutput:   o.NormalizeOutput,
+		Profile:           o.Profile,
+		Dir:               o.Dir,
+		Env:               o.Env,
+		ClearEnv:          o.ClearEnv,
+		TempDir:           o.TempDir,
+		KeepTempOnError:   o.KeepTempOnError,
+		Timeout:           o.Timeout,
+		MaxOutputBytes:    o.MaxOutputBytes,
+	}
+}
+
+// describes powershell.exe to the options shared with the
+// powershell package
+var host = &psscript.Host{
+	Name: "winpowershell",
+	Which: func() (string, error) {
+		if Which() == "" {
+			return WhichOrDefault(), ErrNotFound
+		}
+
+		return Which(), nil
+	},
+	ApartmentFlag:     psscript.ApartmentFlag,
+	ErrApartmentState: ErrApartmentState,
+	TempFiles:         &tempFiles,
+	BeforeRun:         beforeRun,
+	Runner:            runner,
+}
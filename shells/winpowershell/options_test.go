@@ -0,0 +1,106 @@
+package winpowershell
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/jolt9dev/go-exec"
+)
+
+func TestOutputWithTestRunner(t *testing.T) {
+	var got *exec.Cmd
+	SetTestRunner(func(cmd *exec.Cmd) (*exec.PsOutput, error) {
+		got = cmd
+		return &exec.PsOutput{Stdout: []byte("ok\r\n")}, nil
+	})
+	defer ResetTestRunner()
+
+	var before []string
+	BeforeRun = func(cmd *exec.Cmd) { before = cmd.Args }
+	defer func() { BeforeRun = nil }()
+
+	dir := t.TempDir()
+	out, err := NewOptions().WithDir(dir).Output("Get-Date")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out.Stdout) != "ok\n" {
+		t.Errorf("stdout = %q, want the normalized %q", out.Stdout, "ok\n")
+	}
+
+	if got == nil {
+		t.Fatal("the test runner was not called")
+	}
+
+	if got.Dir != dir {
+		t.Errorf("dir = %q, want %q", got.Dir, dir)
+	}
+
+	for _, flag := range []string{"-NoProfile", "-NonInteractive", "-Sta", "-Command"} {
+		if !slices.Contains(got.Args, flag) {
+			t.Errorf("args = %q, want %s", got.Args, flag)
+		}
+	}
+
+	if Which() == "" && !errors.Is(got.Err, ErrNotFound) {
+		t.Errorf("cmd.Err = %v, want ErrNotFound", got.Err)
+	}
+
+	// the runner replaces the process, so BeforeRun is not called
+	if before != nil {
+		t.Errorf("BeforeRun was called with %q", before)
+	}
+}
+
+func TestScriptHereStringUsesEncodedCommand(t *testing.T) {
+	dir := t.TempDir()
+	cmd := NewOptions().WithTempDir(dir).Script("Write-Output @'\nhello\n'@")
+	if !slices.Contains(cmd.Args, "-EncodedCommand") {
+		t.Errorf("args = %q, want -EncodedCommand", cmd.Args)
+	}
+}
+
+func TestApartmentState(t *testing.T) {
+	tests := []struct {
+		state string
+		flag  string
+		err   error
+	}{
+		{"STA", "-Sta", nil},
+		{"mta", "-Mta", nil},
+		{"", "", nil},
+		{"both", "", ErrApartmentState},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			cmd := NewOptions().WithApartmentState(tt.state).Script("Get-Date")
+			if tt.flag != "" && !slices.Contains(cmd.Args, tt.flag) {
+				t.Errorf("args = %q, want %s", cmd.Args, tt.flag)
+			}
+
+			// a missing powershell.exe is reported first
+			if tt.err != nil && Which() != "" && !errors.Is(cmd.Err, tt.err) {
+				t.Errorf("cmd.Err = %v, want %v", cmd.Err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewOptionsDefaults(t *testing.T) {
+	dir := t.TempDir()
+	Defaults.Cwd = dir
+	Defaults.Env = map[string]string{"SPAWN_TEST": "1"}
+	defer func() { Defaults = CommandDefaults{} }()
+
+	o := NewOptions()
+	if o.Dir != dir || o.Env["SPAWN_TEST"] != "1" {
+		t.Errorf("options = %+v, want the defaults", o)
+	}
+
+	if cmd := New("-Command", "Get-Date"); cmd.Dir != dir {
+		t.Errorf("New dir = %q, want %q", cmd.Dir, dir)
+	}
+}
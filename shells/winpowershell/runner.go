@@ -0,0 +1,49 @@
+package winpowershell
+
+import (
+	"sync"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/shells/powershell"
+)
+
+// Runner runs a command in place of spawning a process.
+type Runner = powershell.Runner
+
+var testRunner struct {
+	sync.RWMutex
+	fn Runner
+}
+
+// Sets a runner that is called instead of spawning a process for
+// Run and Output, including the Options methods, so that code
+// calling Windows PowerShell can be unit tested on any platform.
+// The runner receives the fully constructed command. Commands that
+// would fail because powershell.exe is not installed still reach
+// the runner with cmd.Err set. Call ResetTestRunner when the test
+// completes.
+//
+// Example:
+//
+//	winpowershell.SetTestRunner(func(cmd *exec.Cmd) (*exec.PsOutput, error) {
+//		return &exec.PsOutput{Stdout: []byte("ok\n")}, nil
+//	})
+//	defer winpowershell.ResetTestRunner()
+func SetTestRunner(fn Runner) {
+	testRunner.Lock()
+	defer testRunner.Unlock()
+	testRunner.fn = fn
+}
+
+// Removes the runner set with SetTestRunner so that commands
+// spawn processes again
+func ResetTestRunner() {
+	SetTestRunner(nil)
+}
+
+// returns the test runner or nil when none is set
+func runner() func(cmd *exec.Cmd) (*exec.PsOutput, error) {
+	testRunner.RLock()
+	defer testRunner.RUnlock()
+	return testRunner.fn
+}
@@ -0,0 +1,151 @@
+// Package winpowershell runs scripts with Windows PowerShell
+// (powershell.exe) and never with PowerShell Core (pwsh), which
+// the powershell package prefers. Commands default to -NoProfile,
+// -ExecutionPolicy Bypass and -Sta, which scripts that use COM,
+// WinForms or WPF need. Windows PowerShell only exists on Windows,
+// so on other platforms Which returns an empty string and commands
+// fail with ErrNotFound when run.
+//
+// Example:
+//
+//	out, err := winpowershell.Output("$PSVersionTable.PSVersion.ToString()")
+package winpowershell
+
+import (
+	"errors"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/psscript"
+	"github.com/jolt9dev/go-spawn/shells/powershell"
+)
+
+// ErrNotFound is returned when running a command and powershell.exe
+// cannot be found, which is always the case on platforms other than
+// Windows.
+var ErrNotFound = errors.New("winpowershell: powershell.exe not found")
+
+// Returns the path to the Windows PowerShell (powershell.exe)
+// executable or an empty string, see powershell.WhichWindows. The
+// path is resolved in order from POWERSHELL_PATH, then the known
+// locations and then the PATH. Always returns an empty string on
+// non-Windows platforms. The resolved path is cached until
+// ResetWhichCache is called.
+func Which() string {
+	return powershell.WhichWindows()
+}
+
+// Clears the cached path resolved by Which so that the next
+// call probes the file system again, e.g. after PATH changes.
+func ResetWhichCache() {
+	powershell.ResetWhichCache()
+}
+
+// Returns the path to the powershell.exe executable or the
+// default which is the name of the executable without a path
+// or extension.
+func WhichOrDefault() string {
+	exe := Which()
+	if exe == "" {
+		return "powershell"
+	}
+
+	return exe
+}
+
+// Creates a new Windows PowerShell command with the given arguments
+// using vardiac arguments. None of the default flags are added.
+//
+// Example:
+//
+//	winpowershell.New("-NoProfile", "-Command", "Write-Host hello").Run()
+func New(args ...string) *exec.Cmd {
+	cmd := applyDefaults(exec.New(WhichOrDefault(), args...))
+	if Which() == "" {
+		cmd.Err = ErrNotFound
+	}
+
+	return cmd
+}
+
+// Creates a new Windows PowerShell command with the given arguments
+// using a single string which is split with powershell quoting
+// rules, see powershell.SplitArgs. An error from splitting is
+// returned when the command is run.
+//
+// Example:
+//
+//	winpowershell.Command("-NoProfile -Command 'Write-Host hello'").Run()
+func Command(args string) *exec.Cmd {
+	split, err := psscript.SplitArgs(args)
+	cmd := New(split...)
+	if err != nil {
+		cmd.Err = err
+	}
+
+	return cmd
+}
+
+// Creates a new Windows PowerShell command with the given script
+// file
+//
+// Example:
+//
+//	winpowershell.File("script.ps1").Run()
+func File(file string) *exec.Cmd {
+	return NewOptions().File(file)
+}
+
+// Creates a new Windows PowerShell command with the given inline
+// script or file. However, the file must have a .ps1 extension
+// and be on a single line. Scripts with here-strings are passed
+// using -EncodedCommand, while Run and Output write them to a temp
+// .ps1 file that is removed after the command completes.
+//
+// Example:
+//
+//	winpowershell.Script(`Add-Type -AssemblyName System.Windows.Forms
+//	[System.Windows.Forms.Clipboard]::SetText("hello")`).Run()
+//	winpowershell.Script("C:\\scripts\\build.ps1").Output()
+func Script(script string) *exec.Cmd {
+	return NewOptions().Script(script)
+}
+
+// Run a new Windows PowerShell inline script or file.
+// When using a file, the file must have a .ps1 extension
+// and be on a single line.
+// Run will set stdout and stderr to inherit and not
+// capture the output.
+//
+// Example:
+//
+//	winpowershell.Run("Get-Service | Where-Object Status -eq Running")
+//	winpowershell.Run("C:\\scripts\\build.ps1")
+func Run(script string) (*exec.PsOutput, error) {
+	return NewOptions().Run(script)
+}
+
+// Output a new Windows PowerShell inline script or file.
+// When using a file, the file must have a .ps1 extension
+// and be on a single line.
+// Output will set stdout and stderr to piped and captures
+// the standard output and error streams
+//
+// Example:
+//
+//	out, err := winpowershell.Output("C:\\scripts\\build.ps1")
+//	if err != nil || out.Code != 0 {
+//	// handle error
+//	}
+func Output(script string) (*exec.PsOutput, error) {
+	return NewOptions().Output(script)
+}
+
+// Returns s quoted as a powershell single quoted string so that
+// it can be safely interpolated into script text.
+//
+// Example:
+//
+//	winpowershell.Run("Remove-Item -Recurse -LiteralPath " + winpowershell.Quote(dir))
+func Quote(s string) string {
+	return psscript.Quote(s)
+}
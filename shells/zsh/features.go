@@ -0,0 +1,31 @@
+package zsh
+
+import "github.com/jolt9dev/go-spawn/shells"
+
+// Returns the features of the resolved zsh, which supports each of
+// them. The zero value is returned when zsh is not installed.
+//
+// Example:
+//
+//	if zsh.Features().Arrays {
+//		zsh.Run(`files=(*.go); echo ${#files}`)
+//	}
+func Features() shells.ShellFeatures {
+	if Which() == "" {
+		return shells.ShellFeatures{}
+	}
+
+	return features()
+}
+
+// returns the features of zsh, which the flags of its commands
+// are also derived from
+func features() shells.ShellFeatures {
+	return shells.ShellFeatures{
+		ErrExit:             true,
+		PipeFail:            true,
+		NoUnset:             true,
+		ProcessSubstitution: true,
+		Arrays:              true,
+	}
+}
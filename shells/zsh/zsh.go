@@ -0,0 +1,175 @@
+package zsh
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jolt9dev/go-exec"
+	"github.com/jolt9dev/go-spawn/internal/lookup"
+	"github.com/jolt9dev/go-spawn/internal/proc"
+)
+
+func init() {
+	exec.Register("zsh", &exec.Executable{
+		Name:     "zsh",
+		Variable: "ZSH_PATH",
+		Linux: []string{
+			"/bin/zsh",
+			"/usr/bin/zsh",
+		},
+		Darwin: []string{
+			"/bin/zsh",
+			"/opt/homebrew/bin/zsh",
+			"/usr/local/bin/zsh",
+		},
+	})
+}
+
+// Returns the path to the zsh executable or an empty string.
+// The path is resolved in order from ZSH_PATH, which may be quoted
+// and contain variables such as ${HOME}, then the known locations
+// and then the PATH.
+func Which() string {
+	if exe := WhichFromEnv(); exe != "" {
+		return exe
+	}
+
+	exe, _ := exec.Find("zsh")
+	return exe
+}
+
+// Returns the unquoted and expanded path from ZSH_PATH when it
+// is an existing file, otherwise an empty string.
+//
+// Example:
+//
+//	if zsh.WhichFromEnv() == "" {
+//		log.Println("ZSH_PATH is not set, probing for zsh")
+//	}
+func WhichFromEnv() string {
+	return lookup.FromEnv("ZSH_PATH")
+}
+
+// Returns the path to the zsh executable or the default
+// which is the name of the executable without a path or
+// extension when it is not found.
+func WhichOrDefault() string {
+	exe := Which()
+	if exe == "" {
+		return "zsh"
+	}
+
+	return exe
+}
+
+// Returns the locations probed for zsh on the current os
+// in order with environment variables expanded, starting with
+// ZSH_PATH when set. PATH is searched when none of them exist.
+//
+// Example:
+//
+//	fmt.Println("searched:", strings.Join(zsh.Candidates(), ", "))
+func Candidates() []string {
+	return lookup.Candidates("zsh")
+}
+
+// Creates a new zsh command with the given arguments
+// using vardiac arguments
+//
+// Example:
+//
+//	zsh.New("--no-rcs", "-e", "-o", "pipefail", "-c", "echo hello").Run()
+func New(args ...string) *exec.Cmd {
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Creates a new zsh command with the given arguments
+// using a single string
+//
+// Example:
+//
+//	zsh.Command("--no-rcs -e -o pipefail -c 'echo hello'").Run()
+func Command(args string) *exec.Cmd {
+	return exec.New(WhichOrDefault(), exec.SplitArgs(args)...)
+}
+
+// Creates a new zsh command with the given script file
+//
+// Example:
+//
+//	zsh.File("script.zsh").Run()
+func File(file string) *exec.Cmd {
+	args := append(flags(), file)
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Creates a new zsh command with the given inline script
+// or file. However, the file must have a .zsh or .sh extension
+// and be on a single line.
+//
+// Example:
+//
+//	zsh.Script(`brew install age \
+//	  curl \
+//	  zip`).WithCwd("/path/to/dir").Run()
+//	zsh.Script("/path/to/script.zsh").Output()
+func Script(script string) *exec.Cmd {
+	if !strings.ContainsAny(script, "\n") {
+		script = strings.TrimSpace(script)
+
+		if strings.HasSuffix(script, ".zsh") || strings.HasSuffix(script, ".sh") {
+			return File(script)
+		}
+	}
+
+	args := append(flags(), "-c", script)
+	return exec.New(WhichOrDefault(), args...)
+}
+
+// Run a new zsh inline script or file.
+// When using a file, the file must have a .zsh or .sh extension
+// and be on a single line.
+// Run will set stdout and stderr to inherit and not
+// capture the output.
+//
+// Example:
+//
+//	zsh.Run(`brew install age \
+//	  curl \
+//	  zip`)
+//	zsh.Run("/path/to/script.zsh")
+func Run(script string) (*exec.PsOutput, error) {
+	return proc.Run(context.Background(), Script(script))
+}
+
+// Output a new zsh inline script or file.
+// When using a file, the file must have a .zsh or .sh extension
+// and be on a single line.
+// Output will set stdout and stderr to piped and captures
+// the standard output and error streams
+//
+// Example:
+//
+//	out, err := zsh.Output("/path/to/script.zsh")
+//	if err != nil || out.Code != 0 {
+//	// handle error
+//	}
+func Output(script string) (*exec.PsOutput, error) {
+	return proc.Output(context.Background(), Script(script))
+}
+
+// returns the flags that precede the script, derived from the
+// features of zsh
+func flags() []string {
+	f := features()
+	flags := []string{"--no-rcs"}
+	if f.ErrExit {
+		flags = append(flags, "-e")
+	}
+
+	if f.PipeFail {
+		flags = append(flags, "-o", "pipefail")
+	}
+
+	return flags
+}